@@ -12,6 +12,11 @@ func main() {
 	router := http.NewServeMux()
 	router.HandleFunc("/error", HandleError)
 
+	// Bind decodes and validates the request body before the handler is called
+	// and answers with 400, 413 or 415 if that fails.
+	v := validator.NewValidator()
+	router.Handle("POST /error/bound", validator.Bind[Error](v)(http.HandlerFunc(HandleBoundError)))
+
 	server := &http.Server{
 		Addr:              ":1234",
 		Handler:           router,
@@ -62,3 +67,9 @@ func HandleError(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func HandleBoundError(w http.ResponseWriter, r *http.Request) {
+	// The error was already validated by the Bind middleware with the `vld` tag.
+	newError, _ := validator.FromContext[Error](r.Context())
+	fmt.Fprintf(w, "created error with status code %v", newError.StatusCode)
+}
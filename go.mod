@@ -1,6 +1,6 @@
 module github.com/siherrmann/validator
 
-go 1.21.4
+go 1.22
 
 require github.com/stretchr/testify v1.10.0
 
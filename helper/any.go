@@ -3,6 +3,7 @@ package helper
 import (
 	"encoding/json"
 	"fmt"
+	"mime/multipart"
 	"reflect"
 	"strconv"
 	"time"
@@ -14,6 +15,11 @@ func AnyToFloat(v any) (float64, error) {
 	switch v := v.(type) {
 	case string:
 		return float64(len(v)), nil
+	case *multipart.FileHeader:
+		if v == nil {
+			return 0, nil
+		}
+		return float64(v.Size), nil
 	case time.Time:
 		return float64(v.Unix()), nil
 	case int:
@@ -102,6 +108,30 @@ func AnyToArrayOfString(v any) ([]string, error) {
 	}
 }
 
+// basicKindTypes are the unnamed types of the basic kinds.
+var basicKindTypes = map[reflect.Kind]reflect.Type{
+	reflect.Bool:    reflect.TypeOf(false),
+	reflect.Int:     reflect.TypeOf(int(0)),
+	reflect.Int8:    reflect.TypeOf(int8(0)),
+	reflect.Int16:   reflect.TypeOf(int16(0)),
+	reflect.Int32:   reflect.TypeOf(int32(0)),
+	reflect.Int64:   reflect.TypeOf(int64(0)),
+	reflect.Uint:    reflect.TypeOf(uint(0)),
+	reflect.Uint8:   reflect.TypeOf(uint8(0)),
+	reflect.Uint16:  reflect.TypeOf(uint16(0)),
+	reflect.Uint32:  reflect.TypeOf(uint32(0)),
+	reflect.Uint64:  reflect.TypeOf(uint64(0)),
+	reflect.Float32: reflect.TypeOf(float32(0)),
+	reflect.Float64: reflect.TypeOf(float64(0)),
+	reflect.String:  reflect.TypeOf(""),
+}
+
+// IsBasicType checks if the type has a basic kind (bool, numbers and string), also for named types like time.Duration.
+func IsBasicType(t reflect.Type) bool {
+	_, ok := basicKindTypes[t.Kind()]
+	return ok
+}
+
 func AnyToType(in any, expected reflect.Type) (out any, err error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -145,6 +175,15 @@ func AnyToType(in any, expected reflect.Type) (out any, err error) {
 		return ptrValue.Interface(), nil
 	}
 
+	// Named types of basic kinds (eg. `type Priority int`) are converted like their kind.
+	if kindType, ok := basicKindTypes[expected.Kind()]; ok && expected != kindType {
+		out, err := AnyToType(in, kindType)
+		if err != nil {
+			return nil, err
+		}
+		return reflect.ValueOf(out).Convert(expected).Interface(), nil
+	}
+
 	switch expKind := expected.Kind(); expKind {
 	case reflect.String:
 		if v, ok := in.(string); ok {
@@ -301,6 +301,34 @@ func TestAnyToType(t *testing.T) {
 			expected:      any("apple"),
 			expectedError: false,
 		},
+		// named basic kinds
+		{
+			name: "Valid float to named int",
+			args: args{
+				v:        2.0,
+				expected: reflect.TypeOf(testLevel(0)),
+			},
+			expected:      any(testLevel(2)),
+			expectedError: false,
+		},
+		{
+			name: "Valid string to named int",
+			args: args{
+				v:        "3",
+				expected: reflect.TypeOf(time.Duration(0)),
+			},
+			expected:      any(time.Duration(3)),
+			expectedError: false,
+		},
+		{
+			name: "Invalid string to named int",
+			args: args{
+				v:        "a",
+				expected: reflect.TypeOf(testLevel(0)),
+			},
+			expected:      nil,
+			expectedError: true,
+		},
 		// bool
 		{
 			name: "Valid bool to bool",
@@ -1336,3 +1364,11 @@ func TestAnyToType(t *testing.T) {
 		})
 	}
 }
+
+func TestIsBasicType(t *testing.T) {
+	assert.True(t, IsBasicType(reflect.TypeOf("")), "Expected string to be a basic type")
+	assert.True(t, IsBasicType(reflect.TypeOf(uint8(0))), "Expected uint8 to be a basic type")
+	assert.True(t, IsBasicType(reflect.TypeOf(time.Second)), "Expected named int to be a basic type")
+	assert.False(t, IsBasicType(reflect.TypeOf(time.Time{})), "Expected struct not to be a basic type")
+	assert.False(t, IsBasicType(reflect.TypeOf([]string{})), "Expected slice not to be a basic type")
+}
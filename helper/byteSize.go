@@ -0,0 +1,37 @@
+package helper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var byteSizeUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// ByteSizeToInt converts a byte size string like `512`, `100KB`, `5MB` or `1GB` to bytes.
+// Units are case insensitive and based on 1024.
+func ByteSizeToInt(in string) (int64, error) {
+	s := strings.ToUpper(strings.TrimSpace(in))
+	multiplier := int64(1)
+	for _, unit := range byteSizeUnits {
+		if strings.HasSuffix(s, unit.suffix) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, unit.suffix))
+			multiplier = unit.multiplier
+			break
+		}
+	}
+
+	size, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || size < 0 {
+		return 0, fmt.Errorf("invalid byte size: %v", in)
+	}
+	return size * multiplier, nil
+}
@@ -0,0 +1,37 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestByteSizeToInt(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{"Bytes without unit", "512", 512, false},
+		{"Bytes with unit", "512B", 512, false},
+		{"Kilobytes", "100KB", 100 << 10, false},
+		{"Megabytes lowercase", "5mb", 5 << 20, false},
+		{"Gigabytes with space", "1 GB", 1 << 30, false},
+		{"Invalid", "abc", 0, true},
+		{"Negative", "-1", 0, true},
+		{"Empty", "", 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ByteSizeToInt(test.input)
+			if test.wantErr {
+				assert.Error(t, err, "Expected an error converting byte size")
+			} else {
+				assert.NoError(t, err, "Expected no error converting byte size")
+				assert.Equal(t, test.want, got, "Expected byte size to match")
+			}
+		})
+	}
+}
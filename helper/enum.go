@@ -0,0 +1,71 @@
+package helper
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/siherrmann/validator/model"
+)
+
+var enumType = reflect.TypeOf((*model.Enum)(nil)).Elem()
+
+// EnumValues returns the values of the enum type (see model.Enum) of the given type,
+// of its elements for slices and arrays or of its keys for maps.
+// It returns false if the type is no enum type.
+func EnumValues(t reflect.Type) ([]any, bool) {
+	if t == nil {
+		return nil, false
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if !t.Implements(enumType) && !reflect.PointerTo(t).Implements(enumType) {
+			return EnumValues(t.Elem())
+		}
+	case reflect.Map:
+		if !t.Implements(enumType) && !reflect.PointerTo(t).Implements(enumType) {
+			return EnumValues(t.Key())
+		}
+	}
+
+	if t.Implements(enumType) {
+		return reflect.Zero(t).Interface().(model.Enum).Values(), true
+	} else if reflect.PointerTo(t).Implements(enumType) {
+		return reflect.New(t).Interface().(model.Enum).Values(), true
+	}
+	return nil, false
+}
+
+// EnumValueSet creates a ValueSet from enum values, the values are converted with AnyToValueSetKey.
+func EnumValueSet(values []any) (model.ValueSet, error) {
+	keys := []string{}
+	for _, value := range values {
+		key, err := AnyToValueSetKey(value)
+		if err != nil {
+			return nil, fmt.Errorf("error converting enum value: %v", err)
+		}
+		keys = append(keys, key)
+	}
+	return model.NewValueSet(keys...), nil
+}
+
+// AnyToValueSetKey converts a value to its key in a ValueSet. Named types are converted by their kind
+// and numbers are formatted without trailing zeros, so `1`, `int64(1)` and `1.0` have the same key.
+func AnyToValueSetKey(v any) (string, error) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("unsupported type for value: %T", v)
+	}
+}
@@ -0,0 +1,92 @@
+package helper
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/siherrmann/validator/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testStatus string
+
+func (testStatus) Values() []any {
+	return []any{testStatus("active"), testStatus("inactive")}
+}
+
+type testLevel int
+
+func (*testLevel) Values() []any {
+	return []any{testLevel(1), testLevel(2)}
+}
+
+func TestEnumValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    reflect.Type
+		expected []any
+		isEnum   bool
+	}{
+		{name: "Enum type", input: reflect.TypeOf(testStatus("")), expected: []any{testStatus("active"), testStatus("inactive")}, isEnum: true},
+		{name: "Enum type with pointer receiver", input: reflect.TypeOf(testLevel(0)), expected: []any{testLevel(1), testLevel(2)}, isEnum: true},
+		{name: "Slice of enum", input: reflect.TypeOf([]testStatus{}), expected: []any{testStatus("active"), testStatus("inactive")}, isEnum: true},
+		{name: "Array of enum", input: reflect.TypeOf([2]testLevel{}), expected: []any{testLevel(1), testLevel(2)}, isEnum: true},
+		{name: "Map with enum keys", input: reflect.TypeOf(map[testStatus]int{}), expected: []any{testStatus("active"), testStatus("inactive")}, isEnum: true},
+		{name: "Map with enum values", input: reflect.TypeOf(map[string]testStatus{}), expected: nil, isEnum: false},
+		{name: "No enum", input: reflect.TypeOf(""), expected: nil, isEnum: false},
+		{name: "Nil type", input: nil, expected: nil, isEnum: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			values, isEnum := EnumValues(test.input)
+			assert.Equal(t, test.isEnum, isEnum, "Expected enum detection to match")
+			assert.Equal(t, test.expected, values, "Expected enum values to match")
+		})
+	}
+}
+
+func TestEnumValueSet(t *testing.T) {
+	t.Run("Valid enum values", func(t *testing.T) {
+		valueSet, err := EnumValueSet([]any{testStatus("active"), testLevel(1), 2.5, true})
+		require.NoError(t, err, "Expected no error creating value set")
+		assert.Equal(t, model.NewValueSet("active", "1", "2.5", "true"), valueSet, "Expected value set to match")
+	})
+
+	t.Run("Invalid enum values", func(t *testing.T) {
+		_, err := EnumValueSet([]any{[]string{"active"}})
+		assert.Error(t, err, "Expected error for unsupported value")
+	})
+}
+
+func TestAnyToValueSetKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    any
+		expected string
+		wantErr  bool
+	}{
+		{name: "String", input: "a", expected: "a"},
+		{name: "Named string", input: testStatus("active"), expected: "active"},
+		{name: "Int", input: int64(1), expected: "1"},
+		{name: "Uint", input: uint8(1), expected: "1"},
+		{name: "Float without fraction", input: 1.0, expected: "1"},
+		{name: "Float", input: 1.5, expected: "1.5"},
+		{name: "Bool", input: false, expected: "false"},
+		{name: "Unsupported", input: map[string]any{}, wantErr: true},
+		{name: "Nil", input: nil, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			key, err := AnyToValueSetKey(test.input)
+			if test.wantErr {
+				assert.Error(t, err, "Expected error for input: %v", test.input)
+			} else {
+				assert.NoError(t, err, "Expected no error for input: %v", test.input)
+				assert.Equal(t, test.expected, key, "Expected key to match")
+			}
+		})
+	}
+}
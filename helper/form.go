@@ -0,0 +1,517 @@
+package helper
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/siherrmann/validator/model"
+)
+
+// DefaultMaxFormIndex is the highest array index allowed in form keys (eg. `items[1000]`)
+// if no other max index is given.
+const DefaultMaxFormIndex = 1000
+
+// UnmapRequestToJsonMapByType parses the form of the request and unmaps it into a JsonMap
+// by using the field types of the given struct type (see UnmapUrlValuesToJsonMapByType).
+func UnmapRequestToJsonMapByType(request *http.Request, structType reflect.Type, maxIndex int) (map[string]any, error) {
+	if request == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+
+	err := ParseRequestForm(request)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing form: %w", err)
+	}
+
+	return unmapFormToJsonMapByType(request.Form, requestFiles(request), structType, maxIndex)
+}
+
+// UnmapRequestToJsonMapByValidation parses the form of the request and unmaps it into a JsonMap
+// by using the types of the given validations (see UnmapUrlValuesToJsonMapByValidation).
+func UnmapRequestToJsonMapByValidation(request *http.Request, validations []model.Validation, maxIndex int) (map[string]any, error) {
+	if request == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+
+	err := ParseRequestForm(request)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing form: %w", err)
+	}
+
+	return unmapFormToJsonMapByValidation(request.Form, requestFiles(request), validations, maxIndex)
+}
+
+// requestFiles returns the files of a parsed multipart form or nil.
+func requestFiles(request *http.Request) map[string][]*multipart.FileHeader {
+	if request.MultipartForm == nil {
+		return nil
+	}
+	return request.MultipartForm.File
+}
+
+// UnmapUrlValuesToJsonMapByType unmaps url.Values into a JsonMap by using the field types
+// of the given struct type (or pointer to struct type) to convert each value.
+// The keys are the json keys of the fields (or the field names), other keys are ignored.
+//
+// Dotted and bracketed keys are unmapped into nested maps and arrays
+// (eg. `address.city`, `address[city]`, `items[0].name` and `tags[]`).
+// Array indexes greater than maxIndex return an error, a maxIndex <= 0 uses DefaultMaxFormIndex.
+// For more information about the conversion look at FormValuesToType.
+func UnmapUrlValuesToJsonMapByType(values url.Values, structType reflect.Type, maxIndex int) (map[string]any, error) {
+	return unmapFormToJsonMapByType(values, nil, structType, maxIndex)
+}
+
+func unmapFormToJsonMapByType(values url.Values, files map[string][]*multipart.FileHeader, structType reflect.Type, maxIndex int) (map[string]any, error) {
+	for structType != nil && structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType == nil || structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("type has to be of kind struct, was %v", structType)
+	}
+
+	root, err := buildFormTree(values, files, maxIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return formNodeToStructMap(root, structType)
+}
+
+// UnmapUrlValuesToJsonMapByValidation unmaps url.Values into a JsonMap by using the types
+// of the given validations to convert each value. Validations without a type
+// are unmapped like in UnmapUrlValuesToJsonMap, other keys are ignored.
+//
+// Dotted and bracketed keys are unmapped into nested maps and arrays of maps
+// if the validation has an InnerValidation (see UnmapUrlValuesToJsonMapByType).
+// For more information about the conversion look at FormValuesToType.
+func UnmapUrlValuesToJsonMapByValidation(values url.Values, validations []model.Validation, maxIndex int) (map[string]any, error) {
+	return unmapFormToJsonMapByValidation(values, nil, validations, maxIndex)
+}
+
+func unmapFormToJsonMapByValidation(values url.Values, files map[string][]*multipart.FileHeader, validations []model.Validation, maxIndex int) (map[string]any, error) {
+	root, err := buildFormTree(values, files, maxIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return formNodeToValidationMap(root, validations)
+}
+
+// FormValuesToType converts the values of one form key to a json value for the expected type.
+// It returns false if the values are handled as not given.
+//
+// Strings are taken as they are (also if they are empty or look like numbers),
+// empty values for all other types are handled as not given.
+// Numbers are parsed with the bit size of the expected type and returned as int64, uint64 or float64.
+// Booleans are true if any of the values is true (`on`, `true`, `1`, ...), so a hidden `false` input
+// in front of a checkbox works as expected. Slices get one item for every (repeated) non empty value.
+// Maps and structs (except time.Time) are unmarshaled from json if possible.
+func FormValuesToType(values []string, expected reflect.Type) (any, bool, error) {
+	for expected.Kind() == reflect.Ptr {
+		expected = expected.Elem()
+	}
+
+	nonEmpty := []string{}
+	for _, v := range values {
+		if len(strings.TrimSpace(v)) > 0 {
+			nonEmpty = append(nonEmpty, v)
+		}
+	}
+
+	switch expected.Kind() {
+	case reflect.String:
+		if len(values) == 0 {
+			return nil, false, nil
+		}
+		return values[0], true, nil
+	case reflect.Interface:
+		return formValuesToAny(values), len(values) > 0, nil
+	}
+
+	if len(nonEmpty) == 0 {
+		return nil, false, nil
+	}
+
+	switch expected.Kind() {
+	case reflect.Bool:
+		checked := false
+		for _, v := range nonEmpty {
+			b, err := formValueToBool(v)
+			if err != nil {
+				return nil, false, err
+			}
+			checked = checked || b
+		}
+		return checked, true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(strings.TrimSpace(nonEmpty[0]), 10, expected.Bits())
+		if err != nil {
+			return nil, false, fmt.Errorf("error parsing %q to %v: %v", nonEmpty[0], expected, err)
+		}
+		return i, true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(strings.TrimSpace(nonEmpty[0]), 10, expected.Bits())
+		if err != nil {
+			return nil, false, fmt.Errorf("error parsing %q to %v: %v", nonEmpty[0], expected, err)
+		}
+		return u, true, nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(nonEmpty[0]), expected.Bits())
+		if err != nil {
+			return nil, false, fmt.Errorf("error parsing %q to %v: %v", nonEmpty[0], expected, err)
+		}
+		return f, true, nil
+	case reflect.Slice, reflect.Array:
+		if expected.Elem().Kind() == reflect.Uint8 {
+			return nonEmpty[0], true, nil
+		}
+		arrayOut := []any{}
+		for _, v := range nonEmpty {
+			item, ok, err := FormValuesToType([]string{v}, expected.Elem())
+			if err != nil {
+				return nil, false, fmt.Errorf("error converting item %q: %v", v, err)
+			} else if ok {
+				arrayOut = append(arrayOut, item)
+			}
+		}
+		return arrayOut, true, nil
+	case reflect.Map, reflect.Struct:
+		if expected == reflect.TypeOf(time.Time{}) {
+			return nonEmpty[0], true, nil
+		}
+		var unmarshalled any
+		err := json.Unmarshal([]byte(nonEmpty[0]), &unmarshalled)
+		if _, ok := unmarshalled.(map[string]any); err == nil && ok {
+			return unmarshalled, true, nil
+		}
+		return nonEmpty[0], true, nil
+	default:
+		return nonEmpty[0], true, nil
+	}
+}
+
+// formValueToBool converts a single form value to a bool.
+// Case on and off are for checkbox values.
+func formValueToBool(v string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "on":
+		return true, nil
+	case "off":
+		return false, nil
+	default:
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return false, fmt.Errorf("error parsing %q to bool: %v", v, err)
+		}
+		return b, nil
+	}
+}
+
+// formValuesToAny converts form values without a known type by trying to unmarshal them as json.
+func formValuesToAny(values []string) any {
+	if len(values) == 1 {
+		var unmarshalled any
+		if err := json.Unmarshal([]byte(values[0]), &unmarshalled); err == nil {
+			return unmarshalled
+		}
+		return values[0]
+	}
+
+	arrayOut := []any{}
+	for _, v := range values {
+		var unmarshalled any
+		if err := json.Unmarshal([]byte(v), &unmarshalled); err == nil {
+			arrayOut = append(arrayOut, unmarshalled)
+		} else {
+			arrayOut = append(arrayOut, v)
+		}
+	}
+	return arrayOut
+}
+
+// formNode is one node of the tree built from nested form keys.
+// A node either holds values and files (leaf), fields (nested map) or items (indexed array).
+type formNode struct {
+	values []string
+	files  []*multipart.FileHeader
+	fields map[string]*formNode
+	items  map[int]*formNode
+}
+
+func (n *formNode) field(key string) *formNode {
+	if n.fields == nil {
+		n.fields = map[string]*formNode{}
+	}
+	if _, ok := n.fields[key]; !ok {
+		n.fields[key] = &formNode{}
+	}
+	return n.fields[key]
+}
+
+func (n *formNode) item(index int) *formNode {
+	if n.items == nil {
+		n.items = map[int]*formNode{}
+	}
+	if _, ok := n.items[index]; !ok {
+		n.items[index] = &formNode{}
+	}
+	return n.items[index]
+}
+
+// sortedItems returns the items of the node ordered by index. Missing indexes are skipped.
+func (n *formNode) sortedItems() []*formNode {
+	indexes := []int{}
+	for index := range n.items {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	items := []*formNode{}
+	for _, index := range indexes {
+		items = append(items, n.items[index])
+	}
+	return items
+}
+
+// buildFormTree builds a tree of nested form nodes from the form keys of the values and files.
+func buildFormTree(values url.Values, files map[string][]*multipart.FileHeader, maxIndex int) (*formNode, error) {
+	if maxIndex <= 0 {
+		maxIndex = DefaultMaxFormIndex
+	}
+
+	root := &formNode{}
+	for key, keyValues := range values {
+		node, err := root.nodeByFormKey(key, maxIndex)
+		if err != nil {
+			return nil, err
+		}
+		node.values = append(node.values, keyValues...)
+	}
+	for key, keyFiles := range files {
+		node, err := root.nodeByFormKey(key, maxIndex)
+		if err != nil {
+			return nil, err
+		}
+		node.files = append(node.files, keyFiles...)
+	}
+	return root, nil
+}
+
+// nodeByFormKey returns the node for the form key below the root node and creates it if it does not exist.
+func (n *formNode) nodeByFormKey(key string, maxIndex int) (*formNode, error) {
+	segments, err := ParseFormKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	node := n
+	for _, segment := range segments {
+		if index, err := strconv.Atoi(segment); err == nil && node != n {
+			if index < 0 || index > maxIndex {
+				return nil, fmt.Errorf("index %d of form key %v out of range, max index is %d", index, key, maxIndex)
+			}
+			node = node.item(index)
+		} else {
+			node = node.field(segment)
+		}
+	}
+	return node, nil
+}
+
+// ParseFormKey splits a form key into its segments.
+// Dots and brackets separate the segments, so `items[0].name` and `items.0.name`
+// both return `items`, `0` and `name`. A trailing `[]` (eg. `tags[]`) is removed.
+func ParseFormKey(key string) ([]string, error) {
+	segments := []string{}
+	current := strings.Builder{}
+	for i := 0; i < len(key); i++ {
+		switch key[i] {
+		case '.':
+			if current.Len() > 0 {
+				segments = append(segments, current.String())
+				current.Reset()
+			}
+		case '[':
+			if current.Len() > 0 {
+				segments = append(segments, current.String())
+				current.Reset()
+			}
+			end := strings.IndexByte(key[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid form key %v, missing closing bracket", key)
+			}
+			inner := key[i+1 : i+end]
+			i += end
+			if len(inner) == 0 {
+				if i != len(key)-1 {
+					return nil, fmt.Errorf("invalid form key %v, empty brackets are only allowed at the end", key)
+				}
+				continue
+			}
+			segments = append(segments, inner)
+		case ']':
+			return nil, fmt.Errorf("invalid form key %v, missing opening bracket", key)
+		default:
+			current.WriteByte(key[i])
+		}
+	}
+	if current.Len() > 0 {
+		segments = append(segments, current.String())
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("invalid empty form key %v", key)
+	}
+	return segments, nil
+}
+
+// formNodeToStructMap converts the fields of the node into a JsonMap by the fields of the struct type.
+func formNodeToStructMap(node *formNode, structType reflect.Type) (map[string]any, error) {
+	mapOut := map[string]any{}
+	for i := 0; i < structType.NumField(); i++ {
+		fieldType := structType.Field(i)
+		if !fieldType.IsExported() {
+			continue
+		}
+
+		key := fieldJsonKey(fieldType)
+		child, ok := node.fields[key]
+		if !ok {
+			continue
+		}
+
+		value, ok, err := formNodeToType(child, fieldType.Type)
+		if err != nil {
+			return nil, fmt.Errorf("error converting form value %v: %v", key, err)
+		} else if ok {
+			mapOut[key] = value
+		}
+	}
+	return mapOut, nil
+}
+
+// formNodeToType converts a node into a json value for the expected type.
+// Files are only used for *multipart.FileHeader and []*multipart.FileHeader.
+func formNodeToType(node *formNode, expected reflect.Type) (any, bool, error) {
+	switch expected {
+	case reflect.TypeOf(&multipart.FileHeader{}):
+		if len(node.files) == 0 {
+			return nil, false, nil
+		}
+		return node.files[0], true, nil
+	case reflect.TypeOf([]*multipart.FileHeader{}):
+		return node.files, len(node.files) > 0, nil
+	}
+
+	for expected.Kind() == reflect.Ptr {
+		expected = expected.Elem()
+	}
+
+	switch {
+	case len(node.fields) > 0:
+		switch expected.Kind() {
+		case reflect.Struct:
+			if expected != reflect.TypeOf(time.Time{}) {
+				mapOut, err := formNodeToStructMap(node, expected)
+				return mapOut, err == nil, err
+			}
+		case reflect.Map, reflect.Interface:
+			elem := expected
+			if expected.Kind() == reflect.Map {
+				elem = expected.Elem()
+			}
+			mapOut := map[string]any{}
+			for key, child := range node.fields {
+				value, ok, err := formNodeToType(child, elem)
+				if err != nil {
+					return nil, false, fmt.Errorf("error converting form value %v: %v", key, err)
+				} else if ok {
+					mapOut[key] = value
+				}
+			}
+			return mapOut, true, nil
+		}
+		return nil, false, fmt.Errorf("nested form values are not supported for type %v", expected)
+	case len(node.items) > 0:
+		elem := expected
+		switch expected.Kind() {
+		case reflect.Slice, reflect.Array:
+			elem = expected.Elem()
+		case reflect.Interface:
+		default:
+			return nil, false, fmt.Errorf("indexed form values are not supported for type %v", expected)
+		}
+		arrayOut := []any{}
+		for i, child := range node.sortedItems() {
+			value, ok, err := formNodeToType(child, elem)
+			if err != nil {
+				return nil, false, fmt.Errorf("error converting item %d: %v", i, err)
+			} else if ok {
+				arrayOut = append(arrayOut, value)
+			}
+		}
+		return arrayOut, true, nil
+	default:
+		return FormValuesToType(node.values, expected)
+	}
+}
+
+// formNodeToValidationMap converts the fields of the node into a JsonMap by the given validations.
+func formNodeToValidationMap(node *formNode, validations []model.Validation) (map[string]any, error) {
+	mapOut := map[string]any{}
+	for _, validation := range validations {
+		child, ok := node.fields[validation.Key]
+		if !ok {
+			continue
+		}
+
+		value, ok, err := formNodeToValidation(child, validation)
+		if err != nil {
+			return nil, fmt.Errorf("error converting form value %v: %v", validation.Key, err)
+		} else if ok {
+			mapOut[validation.Key] = value
+		}
+	}
+	return mapOut, nil
+}
+
+// formNodeToValidation converts a node into a json value for the given validation.
+func formNodeToValidation(node *formNode, validation model.Validation) (any, bool, error) {
+	if len(validation.InnerValidation) > 0 {
+		switch {
+		case validation.Type == model.Struct && len(node.fields) > 0:
+			mapOut, err := formNodeToValidationMap(node, validation.InnerValidation)
+			return mapOut, err == nil, err
+		case validation.Type == model.Array && len(node.items) > 0:
+			arrayOut := []any{}
+			for i, child := range node.sortedItems() {
+				mapOut, err := formNodeToValidationMap(child, validation.InnerValidation)
+				if err != nil {
+					return nil, false, fmt.Errorf("error converting item %d: %v", i, err)
+				}
+				arrayOut = append(arrayOut, mapOut)
+			}
+			return arrayOut, true, nil
+		}
+	}
+
+	if len(node.files) > 0 {
+		if validation.Type == model.Array {
+			return node.files, true, nil
+		}
+		return node.files[0], true, nil
+	}
+
+	expected := reflect.TypeOf((*any)(nil)).Elem()
+	if len(validation.Type) > 0 && len(node.fields) == 0 && len(node.items) == 0 {
+		expected = validation.Type.ToReflectType()
+	}
+	return formNodeToType(node, expected)
+}
@@ -0,0 +1,319 @@
+package helper
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/siherrmann/validator/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormValuesToType(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []string
+		expected reflect.Type
+		want     any
+		wantOk   bool
+		wantErr  bool
+	}{
+		{"String number", []string{"123"}, reflect.TypeOf(""), "123", true, false},
+		{"String bool", []string{"true"}, reflect.TypeOf(""), "true", true, false},
+		{"String leading zero", []string{"01234"}, reflect.TypeOf(""), "01234", true, false},
+		{"String empty", []string{""}, reflect.TypeOf(""), "", true, false},
+		{"Int", []string{"42"}, reflect.TypeOf(0), int64(42), true, false},
+		{"Int empty", []string{""}, reflect.TypeOf(0), nil, false, false},
+		{"Int invalid", []string{"abc"}, reflect.TypeOf(0), nil, false, true},
+		{"Int8 overflow", []string{"300"}, reflect.TypeOf(int8(0)), nil, false, true},
+		{"Uint", []string{"7"}, reflect.TypeOf(uint(0)), uint64(7), true, false},
+		{"Float", []string{"1.5"}, reflect.TypeOf(0.0), 1.5, true, false},
+		{"Pointer to int", []string{"3"}, reflect.TypeOf(new(int)), int64(3), true, false},
+		{"Bool checkbox on", []string{"on"}, reflect.TypeOf(false), true, true, false},
+		{"Bool hidden and checkbox", []string{"false", "on"}, reflect.TypeOf(false), true, true, false},
+		{"Bool hidden only", []string{"false"}, reflect.TypeOf(false), false, true, false},
+		{"Bool empty", []string{""}, reflect.TypeOf(false), nil, false, false},
+		{"Bool invalid", []string{"maybe"}, reflect.TypeOf(false), nil, false, true},
+		{"Slice of string repeated keys", []string{"a", "", "b"}, reflect.TypeOf([]string{}), []any{"a", "b"}, true, false},
+		{"Slice of int", []string{"1", "2"}, reflect.TypeOf([]int{}), []any{int64(1), int64(2)}, true, false},
+		{"Bytes", []string{"abc"}, reflect.TypeOf([]byte{}), "abc", true, false},
+		{"Map from json", []string{`{"a":"b"}`}, reflect.TypeOf(map[string]string{}), map[string]any{"a": "b"}, true, false},
+		{"Time", []string{"2024-01-01T00:00:00Z"}, reflect.TypeOf(time.Time{}), "2024-01-01T00:00:00Z", true, false},
+		{"Interface", []string{"123"}, reflect.TypeOf((*any)(nil)).Elem(), float64(123), true, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok, err := FormValuesToType(test.values, test.expected)
+			if test.wantErr {
+				assert.Error(t, err, "Expected an error converting form values")
+			} else {
+				assert.NoError(t, err, "Expected no error converting form values")
+				assert.Equal(t, test.wantOk, ok, "Expected ok to match")
+				assert.Equal(t, test.want, got, "Expected converted value to match")
+			}
+		})
+	}
+}
+
+func TestUnmapUrlValuesToJsonMapByType(t *testing.T) {
+	type TestStruct struct {
+		Name    string   `json:"name"`
+		Zip     string   `json:"zip"`
+		Age     int      `json:"age"`
+		Active  bool     `json:"active"`
+		Tags    []string `json:"tags"`
+		NoTag   string
+		private string
+	}
+
+	t.Run("Valid URL values", func(t *testing.T) {
+		values := url.Values{}
+		values.Set("name", "123")
+		values.Set("zip", "01234")
+		values.Set("age", "")
+		values["active"] = []string{"false", "on"}
+		values["tags"] = []string{"a", "b"}
+		values.Set("NoTag", "true")
+		values.Set("unknown", "x")
+
+		mapOut, err := UnmapUrlValuesToJsonMapByType(values, reflect.TypeOf(&TestStruct{}), 0)
+		assert.NoError(t, err, "Expected no error unmapping URL values by type")
+		assert.Equal(t, map[string]any{
+			"name":   "123",
+			"zip":    "01234",
+			"active": true,
+			"tags":   []any{"a", "b"},
+			"NoTag":  "true",
+		}, mapOut, "Expected values to be converted by field type")
+	})
+
+	t.Run("Invalid value", func(t *testing.T) {
+		values := url.Values{}
+		values.Set("age", "abc")
+
+		_, err := UnmapUrlValuesToJsonMapByType(values, reflect.TypeOf(TestStruct{}), 0)
+		assert.Error(t, err, "Expected error for invalid int value")
+		assert.Contains(t, err.Error(), "error converting form value age", "Expected error to contain key")
+	})
+
+	t.Run("Invalid type", func(t *testing.T) {
+		_, err := UnmapUrlValuesToJsonMapByType(url.Values{}, reflect.TypeOf(""), 0)
+		assert.Error(t, err, "Expected error for non struct type")
+	})
+}
+
+func TestUnmapUrlValuesToJsonMapByValidation(t *testing.T) {
+	values := url.Values{}
+	values.Set("name", "true")
+	values.Set("age", "2")
+	values.Set("untyped", "3")
+	values["tags"] = []string{"1", "2"}
+
+	mapOut, err := UnmapUrlValuesToJsonMapByValidation(values, []model.Validation{
+		{Key: "name", Type: model.String},
+		{Key: "age", Type: model.Int},
+		{Key: "tags", Type: model.Array},
+		{Key: "untyped"},
+		{Key: "missing", Type: model.String},
+	}, 0)
+	assert.NoError(t, err, "Expected no error unmapping URL values by validation")
+	assert.Equal(t, map[string]any{
+		"name":    "true",
+		"age":     int64(2),
+		"tags":    []any{"1", "2"},
+		"untyped": float64(3),
+	}, mapOut, "Expected values to be converted by validation type")
+}
+
+func TestUnmapRequestToJsonMapByType(t *testing.T) {
+	type TestStruct struct {
+		Name string `json:"name"`
+	}
+
+	t.Run("Valid form request", func(t *testing.T) {
+		form := url.Values{}
+		form.Set("name", "123")
+		req, err := http.NewRequest("POST", "/", bytes.NewBufferString(form.Encode()))
+		require.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		mapOut, err := UnmapRequestToJsonMapByType(req, reflect.TypeOf(TestStruct{}), 0)
+		assert.NoError(t, err, "Expected no error unmapping request by type")
+		assert.Equal(t, "123", mapOut["name"], "Expected name to stay a string")
+	})
+
+	t.Run("Invalid request", func(t *testing.T) {
+		_, err := UnmapRequestToJsonMapByType(nil, reflect.TypeOf(TestStruct{}), 0)
+		assert.Error(t, err, "Expected error unmapping nil request")
+		_, err = UnmapRequestToJsonMapByValidation(nil, nil, 0)
+		assert.Error(t, err, "Expected error unmapping nil request")
+	})
+}
+
+func TestParseFormKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		want    []string
+		wantErr bool
+	}{
+		{"Simple key", "name", []string{"name"}, false},
+		{"Dotted key", "address.city", []string{"address", "city"}, false},
+		{"Bracketed key", "address[city]", []string{"address", "city"}, false},
+		{"Indexed key", "items[0].name", []string{"items", "0", "name"}, false},
+		{"Append key", "tags[]", []string{"tags"}, false},
+		{"Append key in the middle", "items[].name", nil, true},
+		{"Missing closing bracket", "items[0", nil, true},
+		{"Missing opening bracket", "items0]", nil, true},
+		{"Empty key", "", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseFormKey(test.key)
+			if test.wantErr {
+				assert.Error(t, err, "Expected an error parsing form key")
+			} else {
+				assert.NoError(t, err, "Expected no error parsing form key")
+				assert.Equal(t, test.want, got, "Expected segments to match")
+			}
+		})
+	}
+}
+
+func TestUnmapNestedUrlValuesToJsonMap(t *testing.T) {
+	type Address struct {
+		City string `json:"city"`
+		Zip  string `json:"zip"`
+	}
+	type Item struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+	type TestStruct struct {
+		Address Address        `json:"address"`
+		Items   []Item         `json:"items"`
+		Tags    []string       `json:"tags"`
+		Labels  map[string]int `json:"labels"`
+	}
+
+	values := url.Values{}
+	values.Set("address.city", "Berlin")
+	values.Set("address[zip]", "01234")
+	values.Set("items[1].name", "bar")
+	values.Set("items[0].name", "foo")
+	values.Set("items[0].count", "2")
+	values["tags[]"] = []string{"a", "b"}
+	values.Set("labels.x", "1")
+
+	expected := map[string]any{
+		"address": map[string]any{"city": "Berlin", "zip": "01234"},
+		"items": []any{
+			map[string]any{"name": "foo", "count": int64(2)},
+			map[string]any{"name": "bar"},
+		},
+		"tags":   []any{"a", "b"},
+		"labels": map[string]any{"x": int64(1)},
+	}
+
+	t.Run("By type", func(t *testing.T) {
+		mapOut, err := UnmapUrlValuesToJsonMapByType(values, reflect.TypeOf(TestStruct{}), 0)
+		assert.NoError(t, err, "Expected no error unmapping nested URL values by type")
+		assert.Equal(t, expected, mapOut, "Expected nested maps and arrays")
+	})
+
+	t.Run("By validation", func(t *testing.T) {
+		mapOut, err := UnmapUrlValuesToJsonMapByValidation(values, []model.Validation{
+			{Key: "address", Type: model.Struct, InnerValidation: []model.Validation{
+				{Key: "city", Type: model.String},
+				{Key: "zip", Type: model.String},
+			}},
+			{Key: "items", Type: model.Array, InnerValidation: []model.Validation{
+				{Key: "name", Type: model.String},
+				{Key: "count", Type: model.Int},
+			}},
+			{Key: "tags", Type: model.Array},
+		}, 0)
+		assert.NoError(t, err, "Expected no error unmapping nested URL values by validation")
+		assert.Equal(t, map[string]any{
+			"address": expected["address"],
+			"items":   expected["items"],
+			"tags":    expected["tags"],
+		}, mapOut, "Expected nested maps and arrays")
+	})
+
+	t.Run("Index out of range", func(t *testing.T) {
+		values := url.Values{}
+		values.Set("items[11].name", "foo")
+		_, err := UnmapUrlValuesToJsonMapByType(values, reflect.TypeOf(TestStruct{}), 10)
+		assert.Error(t, err, "Expected error for index greater than max index")
+		assert.Contains(t, err.Error(), "out of range", "Expected out of range error")
+	})
+
+	t.Run("Nested values for unsupported type", func(t *testing.T) {
+		values := url.Values{}
+		values.Set("tags.a", "foo")
+		_, err := UnmapUrlValuesToJsonMapByType(values, reflect.TypeOf(TestStruct{}), 0)
+		assert.Error(t, err, "Expected error for nested values of slice of string")
+	})
+}
+
+func TestUnmapMultipartRequestToJsonMap(t *testing.T) {
+	newRequest := func(t *testing.T) *http.Request {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		require.NoError(t, writer.WriteField("name", "apple"), "Expected no error writing field")
+		for _, name := range []string{"a.txt", "b.txt"} {
+			part, err := writer.CreateFormFile("files", name)
+			require.NoError(t, err, "Expected no error creating file")
+			_, err = part.Write([]byte(name))
+			require.NoError(t, err, "Expected no error writing file")
+		}
+		part, err := writer.CreateFormFile("avatar", "me.png")
+		require.NoError(t, err, "Expected no error creating file")
+		_, err = part.Write([]byte("png"))
+		require.NoError(t, err, "Expected no error writing file")
+		require.NoError(t, writer.Close(), "Expected no error closing writer")
+
+		req, err := http.NewRequest("POST", "/", body)
+		require.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		return req
+	}
+
+	t.Run("By type", func(t *testing.T) {
+		type upload struct {
+			Name   string                  `json:"name"`
+			Avatar *multipart.FileHeader   `json:"avatar"`
+			Files  []*multipart.FileHeader `json:"files"`
+		}
+
+		mapOut, err := UnmapRequestToJsonMapByType(newRequest(t), reflect.TypeOf(upload{}), DefaultMaxFormIndex)
+		assert.NoError(t, err, "Expected no error unmapping multipart request")
+		assert.Equal(t, "apple", mapOut["name"], "Expected name to be 'apple'")
+		require.IsType(t, &multipart.FileHeader{}, mapOut["avatar"], "Expected avatar to be a file")
+		assert.Equal(t, "me.png", mapOut["avatar"].(*multipart.FileHeader).Filename, "Expected avatar file name")
+		require.IsType(t, []*multipart.FileHeader{}, mapOut["files"], "Expected files to be a slice of files")
+		assert.Len(t, mapOut["files"], 2, "Expected two files")
+	})
+
+	t.Run("By validation", func(t *testing.T) {
+		validations := []model.Validation{
+			{Key: "name", Type: model.String},
+			{Key: "avatar", Type: model.Struct},
+			{Key: "files", Type: model.Array},
+		}
+
+		mapOut, err := UnmapRequestToJsonMapByValidation(newRequest(t), validations, DefaultMaxFormIndex)
+		assert.NoError(t, err, "Expected no error unmapping multipart request")
+		assert.Equal(t, "apple", mapOut["name"], "Expected name to be 'apple'")
+		assert.IsType(t, &multipart.FileHeader{}, mapOut["avatar"], "Expected avatar to be a file")
+		assert.Len(t, mapOut["files"], 2, "Expected two files")
+	})
+}
@@ -1,9 +1,14 @@
 package helper
 
 import (
+	"cmp"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
+	"time"
+
+	"github.com/siherrmann/validator/model"
 )
 
 func GetValidMap(in any) (map[string]any, error) {
@@ -54,6 +59,23 @@ func JsonMapToMapKV(m map[string]any, expectedKey reflect.Type, expectedValue re
 }
 
 func MapJsonMapToStruct(jsonMapInput map[string]any, structToUpdate any) error {
+	return mapJsonMapToStruct(jsonMapInput, structToUpdate, nil)
+}
+
+// MapJsonMapToStructWithChanges does the same as MapJsonMapToStruct, but records every value
+// it changes while writing the values into the struct.
+// Nested structs, slices and maps are compared value by value, so the returned ChangeSet
+// contains paths like `address.city` or `items[0].name`.
+func MapJsonMapToStructWithChanges(jsonMapInput map[string]any, structToUpdate any) (model.ChangeSet, error) {
+	changes := model.ChangeSet{}
+	err := mapJsonMapToStruct(jsonMapInput, structToUpdate, &changes)
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func mapJsonMapToStruct(jsonMapInput map[string]any, structToUpdate any, changes *model.ChangeSet) error {
 	err := CheckValidPointerToStruct(structToUpdate)
 	if err != nil {
 		return err
@@ -75,7 +97,12 @@ func MapJsonMapToStruct(jsonMapInput map[string]any, structToUpdate any) error {
 		}
 
 		if jsonValue, ok := jsonMapInput[fieldKey]; ok {
-			err := SetStructValueByJson(field, jsonValue)
+			var err error
+			if changes == nil {
+				err = SetStructValueByJson(field, jsonValue)
+			} else {
+				err = setStructValueByJsonWithChanges(field, jsonValue, fieldKey, changes)
+			}
 			if err != nil {
 				return fmt.Errorf("could not set field %v (json key: %v) of %v: %v", fieldType.Name, jsonKey, reflect.TypeOf(structToUpdate), err.Error())
 			}
@@ -109,3 +136,123 @@ func SetStructValueByJson(fv reflect.Value, jsonValue any) (err error) {
 	}
 	return nil
 }
+
+func setStructValueByJsonWithChanges(fv reflect.Value, jsonValue any, path string, changes *model.ChangeSet) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("error setting struct value: %v", r)
+		}
+	}()
+
+	if fv.IsValid() && fv.CanSet() {
+		converted, err := AnyToType(jsonValue, fv.Type())
+		if err != nil {
+			return err
+		}
+		newValue := reflect.ValueOf(converted)
+		oldValue := reflect.New(fv.Type()).Elem()
+		oldValue.Set(fv)
+		fv.Set(newValue)
+		appendValueChanges(path, oldValue, fv, changes)
+	}
+	return nil
+}
+
+// appendValueChanges compares the old and new value and appends all differences to the changes.
+// Structs, slices, arrays and maps are compared element by element,
+// all other values (including time.Time) are compared as a whole.
+func appendValueChanges(path string, oldValue reflect.Value, newValue reflect.Value, changes *model.ChangeSet) {
+	if !oldValue.IsValid() || !newValue.IsValid() {
+		if oldValue.IsValid() || newValue.IsValid() {
+			*changes = append(*changes, model.Change{Path: path, Old: valueInterface(oldValue), New: valueInterface(newValue)})
+		}
+		return
+	}
+
+	switch newValue.Kind() {
+	case reflect.Ptr:
+		if oldValue.IsNil() || newValue.IsNil() {
+			if oldValue.IsNil() != newValue.IsNil() {
+				*changes = append(*changes, model.Change{Path: path, Old: valueInterface(oldValue), New: valueInterface(newValue)})
+			}
+			return
+		}
+		appendValueChanges(path, oldValue.Elem(), newValue.Elem(), changes)
+	case reflect.Struct:
+		if newValue.Type() == reflect.TypeOf(time.Time{}) || newValue.NumField() == 0 {
+			break
+		}
+		for i := 0; i < newValue.NumField(); i++ {
+			fieldType := newValue.Type().Field(i)
+			if !fieldType.IsExported() {
+				continue
+			}
+			appendValueChanges(path+"."+fieldJsonKey(fieldType), oldValue.Field(i), newValue.Field(i), changes)
+		}
+		return
+	case reflect.Slice, reflect.Array:
+		if newValue.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		for i := 0; i < oldValue.Len() || i < newValue.Len(); i++ {
+			indexPath := fmt.Sprintf("%v[%d]", path, i)
+			if i >= oldValue.Len() {
+				*changes = append(*changes, model.Change{Path: indexPath, New: newValue.Index(i).Interface()})
+			} else if i >= newValue.Len() {
+				*changes = append(*changes, model.Change{Path: indexPath, Old: oldValue.Index(i).Interface()})
+			} else {
+				appendValueChanges(indexPath, oldValue.Index(i), newValue.Index(i), changes)
+			}
+		}
+		return
+	case reflect.Map:
+		for _, key := range sortedMapKeys(newValue) {
+			appendValueChanges(fmt.Sprintf("%v.%v", path, key.Interface()), oldValue.MapIndex(key), newValue.MapIndex(key), changes)
+		}
+		for _, key := range sortedMapKeys(oldValue) {
+			if !newValue.MapIndex(key).IsValid() {
+				*changes = append(*changes, model.Change{Path: fmt.Sprintf("%v.%v", path, key.Interface()), Old: oldValue.MapIndex(key).Interface()})
+			}
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(oldValue.Interface(), newValue.Interface()) {
+		*changes = append(*changes, model.Change{Path: path, Old: oldValue.Interface(), New: newValue.Interface()})
+	}
+}
+
+// sortedMapKeys returns the keys of the map sorted by their value (numbers numerically, all others by their string),
+// so changes of maps are always recorded in the same order.
+func sortedMapKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int {
+		switch {
+		case a.CanInt():
+			return cmp.Compare(a.Int(), b.Int())
+		case a.CanUint():
+			return cmp.Compare(a.Uint(), b.Uint())
+		case a.CanFloat():
+			return cmp.Compare(a.Float(), b.Float())
+		default:
+			return cmp.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+		}
+	})
+	return keys
+}
+
+func valueInterface(v reflect.Value) any {
+	if !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return nil
+	}
+	return v.Interface()
+}
+
+// fieldJsonKey returns the json key of a struct field or the field name if it has no json key.
+func fieldJsonKey(fieldType reflect.StructField) string {
+	jsonKey := strings.Split(fieldType.Tag.Get("json"), ",")[0]
+	if len(jsonKey) > 0 && jsonKey != "-" {
+		return jsonKey
+	}
+	return fieldType.Name
+}
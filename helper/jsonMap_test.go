@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/siherrmann/validator/model"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -614,3 +615,83 @@ func TestMapJsonMapToStruct(t *testing.T) {
 		})
 	})
 }
+
+func TestMapJsonMapToStructWithChanges(t *testing.T) {
+	type Address struct {
+		City string `json:"city"`
+		Zip  string `json:"zip"`
+	}
+	type Item struct {
+		Name string `json:"name"`
+	}
+	type TestStruct struct {
+		Name    string         `json:"name"`
+		Age     int            `json:"age"`
+		Address Address        `json:"address"`
+		Items   []Item         `json:"items"`
+		Tags    []string       `json:"tags"`
+		Labels  map[string]int `json:"labels"`
+	}
+
+	t.Run("Changed simple fields", func(t *testing.T) {
+		result := &TestStruct{Name: "John", Age: 30}
+		changes, err := MapJsonMapToStructWithChanges(map[string]any{"name": "Jane", "age": 30}, result)
+		assert.NoError(t, err, "Expected no error mapping json map to struct with changes")
+		assert.Equal(t, model.ChangeSet{{Path: "name", Old: "John", New: "Jane"}}, changes, "Expected only name to be changed")
+		assert.Equal(t, "Jane", result.Name, "Expected name to be updated")
+	})
+
+	t.Run("Changed nested struct field", func(t *testing.T) {
+		result := &TestStruct{Address: Address{City: "Berlin", Zip: "10115"}}
+		changes, err := MapJsonMapToStructWithChanges(map[string]any{"address": map[string]any{"city": "Hamburg", "zip": "10115"}}, result)
+		assert.NoError(t, err, "Expected no error mapping json map to struct with changes")
+		assert.Equal(t, model.ChangeSet{{Path: "address.city", Old: "Berlin", New: "Hamburg"}}, changes, "Expected only city to be changed")
+		assert.Equal(t, []string{"address"}, changes.Columns(), "Expected address column")
+	})
+
+	t.Run("Changed slice of structs", func(t *testing.T) {
+		result := &TestStruct{Items: []Item{{Name: "apple"}, {Name: "banana"}}}
+		changes, err := MapJsonMapToStructWithChanges(map[string]any{"items": []any{map[string]any{"name": "apple"}}}, result)
+		assert.NoError(t, err, "Expected no error mapping json map to struct with changes")
+		assert.Equal(t, model.ChangeSet{{Path: "items[1]", Old: Item{Name: "banana"}}}, changes, "Expected removed item")
+	})
+
+	t.Run("Changed slice and map values", func(t *testing.T) {
+		result := &TestStruct{Tags: []string{"a"}, Labels: map[string]int{"x": 1, "y": 2}}
+		changes, err := MapJsonMapToStructWithChanges(map[string]any{
+			"tags":   []any{"a", "b"},
+			"labels": map[string]any{"x": 1.0, "z": 3.0},
+		}, result)
+		assert.NoError(t, err, "Expected no error mapping json map to struct with changes")
+		assert.Equal(t, model.ChangeSet{
+			{Path: "tags[1]", New: "b"},
+			{Path: "labels.z", New: 3},
+			{Path: "labels.y", Old: 2},
+		}, changes, "Expected added tag and changed labels")
+	})
+
+	t.Run("Changed map values in key order", func(t *testing.T) {
+		type Scores struct {
+			Scores map[int]int `json:"scores"`
+		}
+		for i := 0; i < 10; i++ {
+			result := &Scores{Scores: map[int]int{1: 1, 2: 2, 10: 10, 20: 20}}
+			changes, err := MapJsonMapToStructWithChanges(map[string]any{"scores": map[string]any{"20": 0, "10": 0, "2": 0, "1": 0}}, result)
+			assert.NoError(t, err, "Expected no error mapping json map to struct with changes")
+			assert.Equal(t, []string{"scores.1", "scores.2", "scores.10", "scores.20"}, changes.Paths(), "Expected changes sorted by key")
+		}
+	})
+
+	t.Run("No changes", func(t *testing.T) {
+		result := &TestStruct{Name: "John"}
+		changes, err := MapJsonMapToStructWithChanges(map[string]any{"name": "John"}, result)
+		assert.NoError(t, err, "Expected no error mapping json map to struct with changes")
+		assert.Empty(t, changes, "Expected no changes")
+	})
+
+	t.Run("Invalid input - not a pointer", func(t *testing.T) {
+		changes, err := MapJsonMapToStructWithChanges(map[string]any{"name": "John"}, TestStruct{})
+		assert.Error(t, err, "Expected error when input is not a pointer")
+		assert.Nil(t, changes, "Expected nil changes for invalid input")
+	})
+}
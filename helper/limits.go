@@ -0,0 +1,242 @@
+package helper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/siherrmann/validator/model"
+)
+
+// LimitRequestBody limits the body of the request to maxBytes with http.MaxBytesReader.
+// Nothing is done if maxBytes <= 0 or the request has no body.
+func LimitRequestBody(request *http.Request, maxBytes int64) {
+	if request == nil || request.Body == nil || maxBytes <= 0 {
+		return
+	}
+	request.Body = http.MaxBytesReader(nil, request.Body, maxBytes)
+}
+
+// LimitError converts an http.MaxBytesError into an error wrapping model.ErrLimitExceeded.
+// All other errors are returned unchanged.
+func LimitError(err error) error {
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		return fmt.Errorf("%w: request body larger than %d bytes", model.ErrLimitExceeded, maxBytesError.Limit)
+	}
+	return err
+}
+
+// UnmarshalRequestToJsonMapWithLimits does the same as UnmarshalRequestToJsonMap,
+// but enforces the limits while reading and decoding the body.
+// It returns an error wrapping model.ErrLimitExceeded if a limit is exceeded.
+func UnmarshalRequestToJsonMapWithLimits(request *http.Request, limits model.Limits) (map[string]any, error) {
+	if request == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+	if request.Body == nil {
+		return nil, fmt.Errorf("error reading request body: body is nil")
+	}
+	defer request.Body.Close()
+
+	LimitRequestBody(request, limits.MaxBodyBytes)
+	mapOut, err := DecodeJsonToJsonMapWithLimits(request.Body, limits)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling: %w", LimitError(err))
+	}
+	return mapOut, nil
+}
+
+// DecodeJsonToJsonMapWithLimits decodes a json object token by token into a JsonMap.
+// The depth, array, object and string limits are checked while decoding, so a too large
+// value is never fully decoded. MaxBodyBytes is not checked (see LimitRequestBody).
+func DecodeJsonToJsonMapWithLimits(reader io.Reader, limits model.Limits) (map[string]any, error) {
+	decoder := &jsonLimitDecoder{decoder: json.NewDecoder(reader), limits: limits}
+
+	token, err := decoder.decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return nil, fmt.Errorf("json value has to be an object, was %v", token)
+	}
+
+	mapOut, err := decoder.decodeObject(1)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = decoder.decoder.Token()
+	if err != io.EOF {
+		return nil, fmt.Errorf("invalid data after top-level value")
+	}
+	return mapOut, nil
+}
+
+// CheckJsonMapLimits checks the depth, array, object and string limits of an already decoded JsonMap
+// (eg. from a form or an added decoder).
+// It returns an error wrapping model.ErrLimitExceeded if a limit is exceeded.
+func CheckJsonMapLimits(jsonMap map[string]any, limits model.Limits) error {
+	return checkJsonValueLimits(jsonMap, 1, limits)
+}
+
+func checkJsonValueLimits(value any, depth int, limits model.Limits) error {
+	switch v := value.(type) {
+	case map[string]any:
+		err := checkDepthLimit(depth, limits)
+		if err != nil {
+			return err
+		}
+		if limits.MaxObjectKeys > 0 && len(v) > limits.MaxObjectKeys {
+			return fmt.Errorf("%w: object with more than %d keys", model.ErrLimitExceeded, limits.MaxObjectKeys)
+		}
+		for key, item := range v {
+			err = checkStringLimit(key, limits)
+			if err != nil {
+				return err
+			}
+			err = checkJsonValueLimits(item, depth+1, limits)
+			if err != nil {
+				return err
+			}
+		}
+	case []any:
+		err := checkDepthLimit(depth, limits)
+		if err != nil {
+			return err
+		}
+		if limits.MaxArrayLength > 0 && len(v) > limits.MaxArrayLength {
+			return fmt.Errorf("%w: array with more than %d items", model.ErrLimitExceeded, limits.MaxArrayLength)
+		}
+		for _, item := range v {
+			err = checkJsonValueLimits(item, depth+1, limits)
+			if err != nil {
+				return err
+			}
+		}
+	case string:
+		return checkStringLimit(v, limits)
+	}
+	return nil
+}
+
+func checkDepthLimit(depth int, limits model.Limits) error {
+	if limits.MaxDepth > 0 && depth > limits.MaxDepth {
+		return fmt.Errorf("%w: nesting depth greater than %d", model.ErrLimitExceeded, limits.MaxDepth)
+	}
+	return nil
+}
+
+func checkStringLimit(s string, limits model.Limits) error {
+	return checkStringLengthLimit(len(s), limits)
+}
+
+func checkStringLengthLimit(length int, limits model.Limits) error {
+	if limits.MaxStringLength > 0 && length > limits.MaxStringLength {
+		return fmt.Errorf("%w: string longer than %d bytes", model.ErrLimitExceeded, limits.MaxStringLength)
+	}
+	return nil
+}
+
+// jsonLimitDecoder decodes json tokens into JsonMap values and checks the limits on the way.
+type jsonLimitDecoder struct {
+	decoder *json.Decoder
+	limits  model.Limits
+}
+
+func (d *jsonLimitDecoder) decodeValue(token json.Token, depth int) (any, error) {
+	switch t := token.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			return d.decodeObject(depth)
+		case '[':
+			return d.decodeArray(depth)
+		default:
+			return nil, fmt.Errorf("unexpected delimiter %v", t)
+		}
+	case string:
+		err := checkStringLimit(t, d.limits)
+		if err != nil {
+			return nil, err
+		}
+		return t, nil
+	default:
+		return t, nil
+	}
+}
+
+func (d *jsonLimitDecoder) decodeObject(depth int) (map[string]any, error) {
+	err := checkDepthLimit(depth, d.limits)
+	if err != nil {
+		return nil, err
+	}
+
+	mapOut := map[string]any{}
+	keys := 0
+	for d.decoder.More() {
+		token, err := d.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid object key %v", token)
+		}
+		err = checkStringLimit(key, d.limits)
+		if err != nil {
+			return nil, err
+		}
+		keys++
+		if d.limits.MaxObjectKeys > 0 && keys > d.limits.MaxObjectKeys {
+			return nil, fmt.Errorf("%w: object with more than %d keys", model.ErrLimitExceeded, d.limits.MaxObjectKeys)
+		}
+
+		token, err = d.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		mapOut[key], err = d.decodeValue(token, depth+1)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = d.decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	return mapOut, nil
+}
+
+func (d *jsonLimitDecoder) decodeArray(depth int) ([]any, error) {
+	err := checkDepthLimit(depth, d.limits)
+	if err != nil {
+		return nil, err
+	}
+
+	arrayOut := []any{}
+	for d.decoder.More() {
+		if d.limits.MaxArrayLength > 0 && len(arrayOut) >= d.limits.MaxArrayLength {
+			return nil, fmt.Errorf("%w: array with more than %d items", model.ErrLimitExceeded, d.limits.MaxArrayLength)
+		}
+
+		token, err := d.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		item, err := d.decodeValue(token, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		arrayOut = append(arrayOut, item)
+	}
+
+	_, err = d.decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	return arrayOut, nil
+}
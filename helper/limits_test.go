@@ -0,0 +1,99 @@
+package helper
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/siherrmann/validator/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeJsonToJsonMapWithLimits(t *testing.T) {
+	limits := model.Limits{MaxDepth: 3, MaxArrayLength: 2, MaxObjectKeys: 3, MaxStringLength: 5}
+
+	tests := []struct {
+		name         string
+		input        string
+		limits       model.Limits
+		expected     map[string]any
+		wantErr      bool
+		wantLimitErr bool
+	}{
+		{"Valid without limits", `{"a":{"b":[1,"x",true,null]}}`, model.Limits{}, map[string]any{"a": map[string]any{"b": []any{float64(1), "x", true, nil}}}, false, false},
+		{"Valid within limits", `{"a":{"b":[1,2]},"c":"apple"}`, limits, map[string]any{"a": map[string]any{"b": []any{float64(1), float64(2)}}, "c": "apple"}, false, false},
+		{"Valid unescaped string length", `{"a":"\u00e4\u00e4\n","b":"\ud83d\ude00"}`, limits, map[string]any{"a": "ää\n", "b": "😀"}, false, false},
+		{"Too deep", `{"a":{"b":[[1]]}}`, limits, nil, true, true},
+		{"Too long array", `{"a":[1,2,3]}`, limits, nil, true, true},
+		{"Too long array of objects", `{"a":[{},{},{}]}`, limits, nil, true, true},
+		{"Too many keys", `{"a":1,"b":2,"c":3,"d":4}`, limits, nil, true, true},
+		{"Too many duplicate keys", `{"a":1,"a":2,"a":3,"a":4}`, limits, nil, true, true},
+		{"Too long string", `{"a":"banana"}`, limits, nil, true, true},
+		{"Too long unescaped string", `{"a":"\u00e4\u00e4\u00e4"}`, limits, nil, true, true},
+		{"Too long key", `{"banana":1}`, limits, nil, true, true},
+		{"No object", `[1]`, limits, nil, true, false},
+		{"Invalid json", `{"a":}`, limits, nil, true, false},
+		{"Trailing data", `{"a":1} {}`, limits, nil, true, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mapOut, err := DecodeJsonToJsonMapWithLimits(strings.NewReader(test.input), test.limits)
+			if test.wantErr {
+				assert.Error(t, err, "Expected error decoding json")
+				assert.Equal(t, test.wantLimitErr, errors.Is(err, model.ErrLimitExceeded), "Expected limit error to match")
+			} else {
+				assert.NoError(t, err, "Expected no error decoding json")
+				assert.Equal(t, test.expected, mapOut, "Expected json map to match")
+			}
+		})
+	}
+}
+
+func TestCheckJsonMapLimits(t *testing.T) {
+	limits := model.Limits{MaxDepth: 2, MaxArrayLength: 2, MaxObjectKeys: 2, MaxStringLength: 5}
+
+	assert.NoError(t, CheckJsonMapLimits(map[string]any{"a": []any{"x", 1}, "b": 2}, limits), "Expected no error within limits")
+	assert.ErrorIs(t, CheckJsonMapLimits(map[string]any{"a": []any{[]any{1}}}, limits), model.ErrLimitExceeded, "Expected depth limit error")
+	assert.ErrorIs(t, CheckJsonMapLimits(map[string]any{"a": []any{1, 2, 3}}, limits), model.ErrLimitExceeded, "Expected array limit error")
+	assert.ErrorIs(t, CheckJsonMapLimits(map[string]any{"a": 1, "b": 2, "c": 3}, limits), model.ErrLimitExceeded, "Expected object limit error")
+	assert.ErrorIs(t, CheckJsonMapLimits(map[string]any{"a": "banana"}, limits), model.ErrLimitExceeded, "Expected string limit error")
+	assert.NoError(t, CheckJsonMapLimits(map[string]any{"a": "banana"}, model.Limits{}), "Expected no error without limits")
+}
+
+func TestUnmarshalRequestToJsonMapWithLimits(t *testing.T) {
+	t.Run("Valid body", func(t *testing.T) {
+		req, err := http.NewRequest("POST", "/", bytes.NewBufferString(`{"name":"apple"}`))
+		require.NoError(t, err, "Expected no error creating request")
+
+		mapOut, err := UnmarshalRequestToJsonMapWithLimits(req, model.Limits{MaxBodyBytes: 100})
+		assert.NoError(t, err, "Expected no error unmarshaling request")
+		assert.Equal(t, "apple", mapOut["name"], "Expected name to be 'apple'")
+	})
+
+	t.Run("Too large body", func(t *testing.T) {
+		req, err := http.NewRequest("POST", "/", bytes.NewBufferString(`{"name":"`+strings.Repeat("a", 100)+`"}`))
+		require.NoError(t, err, "Expected no error creating request")
+
+		_, err = UnmarshalRequestToJsonMapWithLimits(req, model.Limits{MaxBodyBytes: 10})
+		assert.ErrorIs(t, err, model.ErrLimitExceeded, "Expected limit error for large body")
+	})
+
+	t.Run("Too large form", func(t *testing.T) {
+		req, err := http.NewRequest("POST", "/", bytes.NewBufferString("name="+strings.Repeat("a", 100)))
+		require.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", MediaTypeForm)
+		LimitRequestBody(req, 10)
+
+		err = ParseRequestForm(req)
+		assert.ErrorIs(t, err, model.ErrLimitExceeded, "Expected limit error for large form")
+	})
+
+	t.Run("Invalid request", func(t *testing.T) {
+		_, err := UnmarshalRequestToJsonMapWithLimits(nil, model.Limits{})
+		assert.Error(t, err, "Expected error for nil request")
+	})
+}
@@ -0,0 +1,146 @@
+package helper
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/siherrmann/validator/model"
+)
+
+// MergeJsonMap merges the validated source map into the target map with the given strategy.
+// Only nested maps and arrays of maps that have an InnerValidation are merged key by key,
+// all other values are replaced. The mergeKey is only used by model.MergeArraysByKey.
+// An empty strategy is handled like model.MergeReplace.
+func MergeJsonMap(target map[string]any, source map[string]any, validations []model.Validation, strategy model.MergeStrategy, mergeKey string) error {
+	if len(strategy) == 0 {
+		strategy = model.MergeReplace
+	}
+	err := model.LookupMergeStrategy(strategy)
+	if err != nil {
+		return err
+	}
+	if strategy == model.MergeArraysByKey && len(mergeKey) == 0 {
+		return fmt.Errorf("merge key is required for merge strategy %s", strategy)
+	}
+
+	for key, sourceValue := range source {
+		validation := getValidationByKey(validations, key)
+		if strategy == model.MergeReplace || validation == nil {
+			target[key] = sourceValue
+			continue
+		}
+
+		targetValue, ok := target[key]
+		if !ok || targetValue == nil || sourceValue == nil {
+			target[key] = sourceValue
+			continue
+		}
+
+		switch validation.Type {
+		case model.Struct, model.Map:
+			targetMap, targetOk := targetValue.(map[string]any)
+			sourceMap, sourceOk := sourceValue.(map[string]any)
+			if !targetOk || !sourceOk || len(validation.InnerValidation) == 0 {
+				target[key] = sourceValue
+				continue
+			}
+			err := MergeJsonMap(targetMap, sourceMap, validation.InnerValidation, strategy, mergeKey)
+			if err != nil {
+				return fmt.Errorf("error merging %v: %v", key, err)
+			}
+		case model.Array:
+			if !IsArray(targetValue) || !IsArray(sourceValue) {
+				target[key] = sourceValue
+				continue
+			}
+			targetArray, err := ArrayToArrayOfAny(targetValue)
+			if err != nil {
+				return fmt.Errorf("error merging %v: %v", key, err)
+			}
+			sourceArray, err := ArrayToArrayOfAny(sourceValue)
+			if err != nil {
+				return fmt.Errorf("error merging %v: %v", key, err)
+			}
+
+			switch {
+			case strategy == model.MergeAppendArrays:
+				target[key] = append(targetArray, sourceArray...)
+			case strategy == model.MergeArraysByKey && len(validation.InnerValidation) > 0:
+				merged, err := mergeArraysByKey(targetArray, sourceArray, validation.InnerValidation, mergeKey)
+				if err != nil {
+					return fmt.Errorf("error merging %v: %v", key, err)
+				}
+				target[key] = merged
+			default:
+				target[key] = sourceValue
+			}
+		default:
+			target[key] = sourceValue
+		}
+	}
+
+	return nil
+}
+
+// mergeArraysByKey merges every map in the source array into the map in the target array
+// with the same value for the merge key. Source items without a match are appended.
+func mergeArraysByKey(targetArray []any, sourceArray []any, validations []model.Validation, mergeKey string) ([]any, error) {
+	merged := append([]any{}, targetArray...)
+	for i, sourceItem := range sourceArray {
+		sourceMap, err := GetValidMap(sourceItem)
+		if err != nil {
+			return nil, fmt.Errorf("error merging item at index %d: %v", i, err)
+		}
+
+		sourceKeyValue, ok := sourceMap[mergeKey]
+		if !ok {
+			merged = append(merged, sourceMap)
+			continue
+		}
+
+		matched := false
+		for _, targetItem := range merged {
+			targetMap, ok := targetItem.(map[string]any)
+			if !ok {
+				continue
+			}
+			if targetKeyValue, exists := targetMap[mergeKey]; exists && mergeKeysEqual(targetKeyValue, sourceKeyValue) {
+				err := MergeJsonMap(targetMap, sourceMap, validations, model.MergeArraysByKey, mergeKey)
+				if err != nil {
+					return nil, fmt.Errorf("error merging item at index %d: %v", i, err)
+				}
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			merged = append(merged, sourceMap)
+		}
+	}
+	return merged, nil
+}
+
+// mergeKeysEqual checks if two values of the merge key are equal. Numbers are equal by their value
+// (eg. `1` and `1.0`), all other values have to be deeply equal, so `1` and `"1"` are different keys.
+func mergeKeysEqual(a any, b any) bool {
+	if isNumber(a) && isNumber(b) {
+		aKey, aErr := AnyToValueSetKey(a)
+		bKey, bErr := AnyToValueSetKey(b)
+		return aErr == nil && bErr == nil && aKey == bKey
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func isNumber(v any) bool {
+	rv := reflect.ValueOf(v)
+	return rv.CanInt() || rv.CanUint() || rv.CanFloat()
+}
+
+func getValidationByKey(validations []model.Validation, key string) *model.Validation {
+	for i := range validations {
+		if validations[i].Key == key {
+			return &validations[i]
+		}
+	}
+	return nil
+}
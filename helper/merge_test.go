@@ -0,0 +1,101 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/siherrmann/validator/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeJsonMap(t *testing.T) {
+	validations := []model.Validation{
+		{Key: "name", Type: model.String, Requirement: "min1"},
+		{Key: "address", Type: model.Struct, Requirement: "-", InnerValidation: []model.Validation{
+			{Key: "city", Type: model.String, Requirement: "min1"},
+			{Key: "zip", Type: model.String, Requirement: "min1"},
+		}},
+		{Key: "tags", Type: model.Array, Requirement: "-"},
+		{Key: "items", Type: model.Array, Requirement: "-", InnerValidation: []model.Validation{
+			{Key: "id", Type: model.Int, Requirement: "min1"},
+			{Key: "name", Type: model.String, Requirement: "min1"},
+		}},
+	}
+
+	newTarget := func() map[string]any {
+		return map[string]any{
+			"name":    "John",
+			"address": map[string]any{"city": "Berlin", "zip": "10115"},
+			"tags":    []any{"a"},
+			"items":   []any{map[string]any{"id": 1.0, "name": "apple"}},
+		}
+	}
+
+	t.Run("Replace", func(t *testing.T) {
+		target := newTarget()
+		err := MergeJsonMap(target, map[string]any{"address": map[string]any{"city": "Hamburg"}}, validations, model.MergeReplace, "")
+		assert.NoError(t, err, "Expected no error merging json map")
+		assert.Equal(t, map[string]any{"city": "Hamburg"}, target["address"], "Expected address to be replaced")
+	})
+
+	t.Run("Empty strategy replaces", func(t *testing.T) {
+		target := newTarget()
+		err := MergeJsonMap(target, map[string]any{"address": map[string]any{"city": "Hamburg"}}, validations, "", "")
+		assert.NoError(t, err, "Expected no error merging json map")
+		assert.Equal(t, map[string]any{"city": "Hamburg"}, target["address"], "Expected address to be replaced")
+	})
+
+	t.Run("Deep", func(t *testing.T) {
+		target := newTarget()
+		err := MergeJsonMap(target, map[string]any{
+			"address": map[string]any{"city": "Hamburg"},
+			"tags":    []any{"b"},
+		}, validations, model.MergeDeep, "")
+		assert.NoError(t, err, "Expected no error merging json map")
+		assert.Equal(t, map[string]any{"city": "Hamburg", "zip": "10115"}, target["address"], "Expected address siblings to be kept")
+		assert.Equal(t, []any{"b"}, target["tags"], "Expected tags to be replaced")
+		assert.Equal(t, "John", target["name"], "Expected name to be unchanged")
+	})
+
+	t.Run("Append arrays", func(t *testing.T) {
+		target := newTarget()
+		err := MergeJsonMap(target, map[string]any{"tags": []any{"b"}}, validations, model.MergeAppendArrays, "")
+		assert.NoError(t, err, "Expected no error merging json map")
+		assert.Equal(t, []any{"a", "b"}, target["tags"], "Expected tags to be appended")
+	})
+
+	t.Run("Merge arrays by key", func(t *testing.T) {
+		target := newTarget()
+		err := MergeJsonMap(target, map[string]any{"items": []any{
+			map[string]any{"id": 1.0, "name": "banana"},
+			map[string]any{"id": 2.0, "name": "cherry"},
+		}}, validations, model.MergeArraysByKey, "id")
+		assert.NoError(t, err, "Expected no error merging json map")
+		assert.Equal(t, []any{
+			map[string]any{"id": 1.0, "name": "banana"},
+			map[string]any{"id": 2.0, "name": "cherry"},
+		}, target["items"], "Expected items to be merged by id")
+	})
+
+	t.Run("Merge arrays by key of other type", func(t *testing.T) {
+		target := newTarget()
+		err := MergeJsonMap(target, map[string]any{"items": []any{
+			map[string]any{"id": "1", "name": "banana"},
+			map[string]any{"id": 1, "name": "cherry"},
+		}}, validations, model.MergeArraysByKey, "id")
+		assert.NoError(t, err, "Expected no error merging json map")
+		assert.Equal(t, []any{
+			map[string]any{"id": 1, "name": "cherry"},
+			map[string]any{"id": "1", "name": "banana"},
+		}, target["items"], "Expected only items with the same id value to be merged")
+	})
+
+	t.Run("Merge arrays by key without key", func(t *testing.T) {
+		err := MergeJsonMap(newTarget(), map[string]any{}, validations, model.MergeArraysByKey, "")
+		assert.Error(t, err, "Expected error without merge key")
+	})
+
+	t.Run("Invalid strategy", func(t *testing.T) {
+		err := MergeJsonMap(newTarget(), map[string]any{}, validations, model.MergeStrategy("invalid"), "")
+		assert.Error(t, err, "Expected error with invalid strategy")
+	})
+}
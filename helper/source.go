@@ -0,0 +1,113 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/siherrmann/validator/model"
+)
+
+// GetRequestSourceValues returns the values with the given name from the source of the request.
+// Path values are read with request.PathValue, so they are only set if the request was routed
+// by a http.ServeMux with a matching wildcard. Body values are not read from the request.
+// It returns false if the source has no value with the name.
+func GetRequestSourceValues(request *http.Request, source model.Source, name string) ([]string, bool) {
+	var values []string
+	switch source {
+	case model.SourcePath:
+		if value := request.PathValue(name); len(value) > 0 {
+			values = append(values, value)
+		}
+	case model.SourceQuery:
+		values = request.URL.Query()[name]
+	case model.SourceHeader:
+		values = request.Header.Values(name)
+	case model.SourceCookie:
+		for _, cookie := range request.Cookies() {
+			if cookie.Name == name {
+				values = append(values, cookie.Value)
+			}
+		}
+	}
+	return values, len(values) > 0
+}
+
+// ApplyRequestSourcesByType sets the values of all struct fields with a source tag (eg. `src:"path"`)
+// from their request source into the jsonMap, converted by the type of the field.
+// Values of these fields in the jsonMap (eg. from the request body) are removed, so the body
+// can never override a path, query, header or cookie value.
+func ApplyRequestSourcesByType(request *http.Request, jsonMap map[string]any, structType reflect.Type) error {
+	for structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("value has to be of kind struct, was %v", structType.Kind())
+	}
+
+	for i := 0; i < structType.NumField(); i++ {
+		fieldType := structType.Field(i)
+		tag, ok := fieldType.Tag.Lookup(model.SRC)
+		if !ok {
+			continue
+		}
+
+		source, name, err := model.GetSource(tag)
+		if err != nil {
+			return fmt.Errorf("error getting source of field %v: %v", fieldType.Name, err)
+		}
+
+		err = applyRequestSource(request, jsonMap, fieldJsonKey(fieldType), source, name, fieldType.Type)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyRequestSourcesByValidation does the same as ApplyRequestSourcesByType for all validations with a source,
+// the values are converted by the types of the validations.
+func ApplyRequestSourcesByValidation(request *http.Request, jsonMap map[string]any, validations []model.Validation) error {
+	for _, validation := range validations {
+		if validation.Source == model.SourceBody {
+			continue
+		}
+
+		expected := reflect.TypeOf((*any)(nil)).Elem()
+		if len(validation.Type) > 0 {
+			expected = validation.Type.ToReflectType()
+		}
+
+		err := applyRequestSource(request, jsonMap, validation.Key, validation.Source, validation.SourceName, expected)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyRequestSource removes the key from the jsonMap and sets the converted value
+// from the request source if it is given. The name defaults to the key.
+func applyRequestSource(request *http.Request, jsonMap map[string]any, key string, source model.Source, name string, expected reflect.Type) error {
+	if source == model.SourceBody {
+		return nil
+	}
+	if len(name) == 0 {
+		name = key
+	}
+
+	delete(jsonMap, key)
+	values, ok := GetRequestSourceValues(request, source, name)
+	if !ok {
+		return nil
+	}
+
+	value, ok, err := FormValuesToType(values, expected)
+	if err != nil {
+		return fmt.Errorf("error converting %v value %v: %v", source, name, err)
+	}
+	if ok {
+		jsonMap[key] = value
+	}
+	return nil
+}
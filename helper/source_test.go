@@ -0,0 +1,103 @@
+package helper
+
+import (
+	"bytes"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/siherrmann/validator/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSourceRequest(t *testing.T) *http.Request {
+	req, err := http.NewRequest("POST", "/users/42?page=2&tag=a&tag=b", bytes.NewBufferString(`{}`))
+	require.NoError(t, err, "Expected no error creating request")
+	req.SetPathValue("id", "42")
+	req.Header.Set("X-Request-ID", "abc")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "s1"})
+	return req
+}
+
+func TestGetRequestSourceValues(t *testing.T) {
+	req := newSourceRequest(t)
+
+	tests := []struct {
+		name     string
+		source   model.Source
+		key      string
+		expected []string
+		found    bool
+	}{
+		{"Path", model.SourcePath, "id", []string{"42"}, true},
+		{"Missing path", model.SourcePath, "name", nil, false},
+		{"Query", model.SourceQuery, "tag", []string{"a", "b"}, true},
+		{"Header", model.SourceHeader, "x-request-id", []string{"abc"}, true},
+		{"Cookie", model.SourceCookie, "session", []string{"s1"}, true},
+		{"Body", model.SourceBody, "id", nil, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			values, ok := GetRequestSourceValues(req, test.source, test.key)
+			assert.Equal(t, test.found, ok, "Expected found to match")
+			assert.Equal(t, test.expected, values, "Expected values to match")
+		})
+	}
+}
+
+func TestApplyRequestSourcesByType(t *testing.T) {
+	type request struct {
+		ID        int      `json:"id" src:"path"`
+		Page      int      `json:"page" src:"query"`
+		Tags      []string `json:"tags" src:"query:tag"`
+		RequestID string   `json:"request_id" src:"header:X-Request-ID"`
+		Session   string   `json:"session" src:"cookie"`
+		Missing   string   `json:"missing" src:"header"`
+		Name      string   `json:"name"`
+	}
+
+	t.Run("Valid sources", func(t *testing.T) {
+		jsonMap := map[string]any{"id": float64(1), "missing": "body", "name": "apple"}
+		err := ApplyRequestSourcesByType(newSourceRequest(t), jsonMap, reflect.TypeOf(&request{}))
+		assert.NoError(t, err, "Expected no error applying request sources")
+		assert.Equal(t, map[string]any{
+			"id":         int64(42),
+			"page":       int64(2),
+			"tags":       []any{"a", "b"},
+			"request_id": "abc",
+			"session":    "s1",
+			"name":       "apple",
+		}, jsonMap, "Expected sources to override the body")
+	})
+
+	t.Run("Invalid source", func(t *testing.T) {
+		type invalid struct {
+			ID int `json:"id" src:"body"`
+		}
+		err := ApplyRequestSourcesByType(newSourceRequest(t), map[string]any{}, reflect.TypeOf(invalid{}))
+		assert.Error(t, err, "Expected error for invalid source")
+	})
+
+	t.Run("Invalid value", func(t *testing.T) {
+		type invalid struct {
+			RequestID int `json:"request_id" src:"header:X-Request-ID"`
+		}
+		err := ApplyRequestSourcesByType(newSourceRequest(t), map[string]any{}, reflect.TypeOf(invalid{}))
+		assert.Error(t, err, "Expected error for header that is no int")
+	})
+}
+
+func TestApplyRequestSourcesByValidation(t *testing.T) {
+	validations := []model.Validation{
+		{Key: "id", Type: model.Int, Source: model.SourcePath},
+		{Key: "request_id", Type: model.String, Source: model.SourceHeader, SourceName: "X-Request-ID"},
+		{Key: "name", Type: model.String},
+	}
+
+	jsonMap := map[string]any{"id": float64(1), "name": "apple"}
+	err := ApplyRequestSourcesByValidation(newSourceRequest(t), jsonMap, validations)
+	assert.NoError(t, err, "Expected no error applying request sources")
+	assert.Equal(t, map[string]any{"id": int64(42), "request_id": "abc", "name": "apple"}, jsonMap, "Expected sources to override the body")
+}
@@ -1,13 +1,85 @@
 package helper
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
+// Media types of request bodies supported by default.
+const (
+	MediaTypeJson          = "application/json"
+	MediaTypeForm          = "application/x-www-form-urlencoded"
+	MediaTypeMultipartForm = "multipart/form-data"
+)
+
+// DefaultMaxMultipartMemory is the max memory used to parse multipart forms,
+// the remaining file parts are stored in temporary files.
+const DefaultMaxMultipartMemory = 32 << 20
+
+// GetMediaType returns the lowercase media type of the Content-Type header of the request without parameters.
+// It returns an empty string if the request has no Content-Type.
+func GetMediaType(request *http.Request) (string, error) {
+	contentType := request.Header.Get("Content-Type")
+	if len(strings.TrimSpace(contentType)) == 0 {
+		return "", nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", fmt.Errorf("error parsing content type %v: %v", contentType, err)
+	}
+	return mediaType, nil
+}
+
+// IsJsonMediaType checks if the media type is application/json or has a json suffix (eg. application/problem+json).
+func IsJsonMediaType(mediaType string) bool {
+	return mediaType == MediaTypeJson || strings.HasSuffix(mediaType, "+json")
+}
+
+// HasRequestBody checks if the request has a body with at least one byte without consuming it,
+// the body of the request is replaced by a reader which returns the checked byte again.
+func HasRequestBody(request *http.Request) (bool, error) {
+	if request == nil || request.Body == nil || request.Body == http.NoBody {
+		return false, nil
+	}
+
+	reader := bufio.NewReader(request.Body)
+	_, err := reader.Peek(1)
+	request.Body = bodyReadCloser{Reader: reader, Closer: request.Body}
+	if err == io.EOF {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// bodyReadCloser is a request body reading from the Reader and closing the original body.
+type bodyReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// ParseRequestForm parses the form of the request.
+// Multipart forms are parsed with DefaultMaxMultipartMemory, all other requests with request.ParseForm.
+// A body limited by LimitRequestBody returns an error wrapping model.ErrLimitExceeded if it is too large.
+func ParseRequestForm(request *http.Request) error {
+	mediaType, err := GetMediaType(request)
+	if err == nil && mediaType == MediaTypeMultipartForm {
+		if request.MultipartForm != nil {
+			return nil
+		}
+		return LimitError(request.ParseMultipartForm(DefaultMaxMultipartMemory))
+	}
+	return LimitError(request.ParseForm())
+}
+
 func UnmarshalRequestToJsonMap(request *http.Request) (map[string]any, error) {
 	if request == nil {
 		return nil, fmt.Errorf("request is nil")
@@ -2,8 +2,10 @@ package helper
 
 import (
 	"bytes"
+	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -96,3 +98,60 @@ func TestUnmapUrlValuesToJsonMap(t *testing.T) {
 		assert.Empty(t, mapOut, "Expected empty JsonMap for empty URL values")
 	})
 }
+
+func TestGetMediaType(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		want        string
+		wantErr     bool
+	}{
+		{"Empty", "", "", false},
+		{"Json", "application/json", MediaTypeJson, false},
+		{"Json with charset", "Application/JSON; charset=utf-8", MediaTypeJson, false},
+		{"Multipart with boundary", "multipart/form-data; boundary=abc", MediaTypeMultipartForm, false},
+		{"Invalid", "application/json; =", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/", nil)
+			require.NoError(t, err, "Expected no error creating request")
+			req.Header.Set("Content-Type", test.contentType)
+
+			got, err := GetMediaType(req)
+			if test.wantErr {
+				assert.Error(t, err, "Expected an error getting media type")
+			} else {
+				assert.NoError(t, err, "Expected no error getting media type")
+				assert.Equal(t, test.want, got, "Expected media type to match")
+			}
+		})
+	}
+}
+
+func TestIsJsonMediaType(t *testing.T) {
+	assert.True(t, IsJsonMediaType(MediaTypeJson), "Expected application/json to be json")
+	assert.True(t, IsJsonMediaType("application/problem+json"), "Expected +json suffix to be json")
+	assert.False(t, IsJsonMediaType(MediaTypeForm), "Expected form not to be json")
+}
+
+func TestHasRequestBody(t *testing.T) {
+	req, _ := http.NewRequest("POST", "/?page=2", strings.NewReader(`{"a":1}`))
+	hasBody, err := HasRequestBody(req)
+	assert.NoError(t, err, "Expected no error checking body")
+	assert.True(t, hasBody, "Expected request to have a body")
+	body, err := io.ReadAll(req.Body)
+	assert.NoError(t, err, "Expected no error reading body")
+	assert.Equal(t, `{"a":1}`, string(body), "Expected body not to be consumed")
+
+	req, _ = http.NewRequest("POST", "/?page=2", strings.NewReader(""))
+	hasBody, err = HasRequestBody(req)
+	assert.NoError(t, err, "Expected no error checking empty body")
+	assert.False(t, hasBody, "Expected request without body")
+
+	req, _ = http.NewRequest("GET", "/?page=2", nil)
+	hasBody, err = HasRequestBody(req)
+	assert.NoError(t, err, "Expected no error checking nil body")
+	assert.False(t, hasBody, "Expected request without body")
+}
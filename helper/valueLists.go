@@ -0,0 +1,151 @@
+package helper
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/siherrmann/validator/model"
+)
+
+// Names of the built-in value lists, usable like `frm@countries`.
+const (
+	// ISO 3166-1 alpha-2 country codes (eg. `DE`)
+	ValueListCountries = "countries"
+	// ISO 4217 currency codes (eg. `EUR`)
+	ValueListCurrencies = "currencies"
+	// ISO 639-1 language codes (eg. `de`)
+	ValueListLanguages = "languages"
+	// IANA time zone names from the local tzdata (eg. `Europe/Berlin`)
+	ValueListTimeZones = "timezones"
+)
+
+const countryCodes = "AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR BS BT BV BW BY BZ " +
+	"CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ DE DJ DK DM DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO FR " +
+	"GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY HK HM HN HR HT HU ID IE IL IM IN IO IQ IR IS IT JE JM JO JP " +
+	"KE KG KH KI KM KN KP KR KW KY KZ LA LB LC LI LK LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO MP MQ MR MS MT MU MV MW MX MY MZ " +
+	"NA NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF PG PH PK PL PM PN PR PS PT PW PY QA RE RO RS RU RW " +
+	"SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV SX SY SZ TC TD TF TG TH TJ TK TL TM TN TO TR TT TV TW TZ " +
+	"UA UG UM US UY UZ VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW"
+
+const currencyCodes = "AED AFN ALL AMD AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BOV BRL BSD BTN BWP BYN BZD " +
+	"CAD CDF CHE CHF CHW CLF CLP CNY COP COU CRC CUP CVE CZK DJF DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD GNF GTQ GYD " +
+	"HKD HNL HTG HUF IDR ILS INR IQD IRR ISK JMD JOD JPY KES KGS KHR KMF KPW KRW KWD KYD KZT LAK LBP LKR LRD LSL LYD " +
+	"MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MXV MYR MZN NAD NGN NIO NOK NPR NZD OMR PAB PEN PGK PHP PKR PLN PYG QAR " +
+	"RON RSD RUB RWF SAR SBD SCR SDG SEK SGD SHP SLE SOS SRD SSP STN SVC SYP SZL THB TJS TMT TND TOP TRY TTD TWD TZS " +
+	"UAH UGX USD USN UYI UYU UYW UZS VED VES VND VUV WST XAF XAG XAU XBA XBB XBC XBD XCD XCG XDR XOF XPD XPF XPT XSU XTS XUA XXX " +
+	"YER ZAR ZMW ZWG"
+
+const languageCodes = "aa ab ae af ak am an ar as av ay az ba be bg bi bm bn bo br bs ca ce ch co cr cs cu cv cy da de dv dz " +
+	"ee el en eo es et eu fa ff fi fj fo fr fy ga gd gl gn gu gv ha he hi ho hr ht hu hy hz ia id ie ig ii ik io is it iu " +
+	"ja jv ka kg ki kj kk kl km kn ko kr ks ku kv kw ky la lb lg li ln lo lt lu lv mg mh mi mk ml mn mr ms mt my " +
+	"na nb nd ne ng nl nn no nr nv ny oc oj om or os pa pi pl ps pt qu rm rn ro ru rw " +
+	"sa sc sd se sg si sk sl sm sn so sq sr ss st su sv sw ta te tg th ti tk tl tn to tr ts tt tw ty " +
+	"ug uk ur uz ve vi vo wa wo xh yi yo za zh zu"
+
+// zoneInfoDirs are the directories searched for tzdata, the same as used by the time package on unix systems.
+var zoneInfoDirs = []string{
+	"/usr/share/zoneinfo/",
+	"/usr/share/lib/zoneinfo/",
+	"/usr/lib/locale/TZ/",
+	"/etc/zoneinfo/",
+}
+
+// builtInValueLists create each built-in value list once on its first use,
+// so a list like countries can be used without reading the tzdata for the time zones.
+var builtInValueLists = map[string]func() model.ValueSet{
+	ValueListCountries: sync.OnceValue(func() model.ValueSet {
+		return model.NewValueSet(strings.Fields(countryCodes)...)
+	}),
+	ValueListCurrencies: sync.OnceValue(func() model.ValueSet {
+		return model.NewValueSet(strings.Fields(currencyCodes)...)
+	}),
+	ValueListLanguages: sync.OnceValue(func() model.ValueSet {
+		return model.NewValueSet(strings.Fields(languageCodes)...)
+	}),
+	ValueListTimeZones: sync.OnceValue(func() model.ValueSet {
+		return model.NewValueSet(TimeZones()...)
+	}),
+}
+
+// BuiltInValueList returns the built-in value list with the name (eg. ValueListCountries).
+// Each list is created once on its first use, so the returned set must not be changed.
+// It returns false if there is no built-in value list with the name.
+func BuiltInValueList(name string) (model.ValueSet, bool) {
+	valueList, ok := builtInValueLists[name]
+	if !ok {
+		return nil, false
+	}
+	return valueList(), true
+}
+
+// BuiltInValueLists returns all built-in value lists by name (countries, currencies, languages and timezones).
+// It creates all lists, use BuiltInValueList to only create a single one.
+func BuiltInValueLists() map[string]model.ValueSet {
+	valueLists := map[string]model.ValueSet{}
+	for name, valueList := range builtInValueLists {
+		valueLists[name] = valueList()
+	}
+	return valueLists
+}
+
+// TimeZones returns the names of the IANA time zones found in the local tzdata
+// (the directory in the ZONEINFO environment variable or the common system directories).
+// It always contains `UTC`.
+func TimeZones() []string {
+	dirs := zoneInfoDirs
+	if zoneInfo := os.Getenv("ZONEINFO"); len(zoneInfo) > 0 {
+		dirs = append([]string{zoneInfo}, dirs...)
+	}
+
+	timeZones := []string{"UTC"}
+	for _, dir := range dirs {
+		info, err := os.Stat(dir)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+
+		_ = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			name, err := filepath.Rel(dir, path)
+			if err != nil {
+				return nil
+			}
+			name = filepath.ToSlash(name)
+			if entry.IsDir() {
+				// posix and right are copies with other leap second handling
+				if name == "posix" || name == "right" {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if name != "UTC" && name != "localtime" && name != "posixrules" && isTzif(path) {
+				timeZones = append(timeZones, name)
+			}
+			return nil
+		})
+		if len(timeZones) > 1 {
+			break
+		}
+	}
+	return timeZones
+}
+
+// isTzif checks if the file starts with the magic bytes of the tzdata file format.
+func isTzif(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	magic := make([]byte, 4)
+	_, err = io.ReadFull(f, magic)
+	return err == nil && bytes.Equal(magic, []byte("TZif"))
+}
@@ -0,0 +1,67 @@
+package helper
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltInValueLists(t *testing.T) {
+	valueLists := BuiltInValueLists()
+
+	t.Run("Countries", func(t *testing.T) {
+		require.Contains(t, valueLists, ValueListCountries, "Expected countries list")
+		assert.Len(t, valueLists[ValueListCountries], 249, "Expected all ISO 3166-1 alpha-2 codes")
+		assert.True(t, valueLists[ValueListCountries].Contains("DE"), "Expected DE to be a country")
+		assert.False(t, valueLists[ValueListCountries].Contains("XX"), "Expected XX not to be a country")
+	})
+
+	t.Run("Currencies", func(t *testing.T) {
+		require.Contains(t, valueLists, ValueListCurrencies, "Expected currencies list")
+		assert.True(t, valueLists[ValueListCurrencies].Contains("EUR"), "Expected EUR to be a currency")
+		assert.False(t, valueLists[ValueListCurrencies].Contains("DEM"), "Expected DEM not to be a currency")
+	})
+
+	t.Run("Languages", func(t *testing.T) {
+		require.Contains(t, valueLists, ValueListLanguages, "Expected languages list")
+		assert.Len(t, valueLists[ValueListLanguages], 183, "Expected all ISO 639-1 codes")
+		assert.True(t, valueLists[ValueListLanguages].Contains("de"), "Expected de to be a language")
+	})
+
+	t.Run("Time zones", func(t *testing.T) {
+		require.Contains(t, valueLists, ValueListTimeZones, "Expected timezones list")
+		assert.True(t, valueLists[ValueListTimeZones].Contains("UTC"), "Expected UTC to be a time zone")
+		assert.False(t, valueLists[ValueListTimeZones].Contains("posixrules"), "Expected posixrules not to be a time zone")
+	})
+}
+
+func TestBuiltInValueList(t *testing.T) {
+	countries, ok := BuiltInValueList(ValueListCountries)
+	require.True(t, ok, "Expected countries list")
+	assert.True(t, countries.Contains("DE"), "Expected DE to be a country")
+
+	_, ok = BuiltInValueList("planets")
+	assert.False(t, ok, "Expected no unknown list")
+}
+
+func TestTimeZones(t *testing.T) {
+	t.Run("Time zones from ZONEINFO", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestFile(t, dir, "Europe/Berlin", "TZif2")
+		writeTestFile(t, dir, "right/Europe/Berlin", "TZif2")
+		writeTestFile(t, dir, "zone.tab", "# tab")
+		t.Setenv("ZONEINFO", dir)
+
+		assert.Equal(t, []string{"UTC", "Europe/Berlin"}, TimeZones(), "Expected time zones from ZONEINFO")
+	})
+}
+
+func writeTestFile(t *testing.T, dir string, name string, content string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755), "Expected no error creating directory")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644), "Expected no error writing file")
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"slices"
+	"strings"
+)
+
+// Change represents a single value change made while updating a struct.
+// The Path is the json path of the changed value (eg. `name`, `address.city` or `items[0].name`).
+// Old and New are the values before and after the update, nil if the value did not exist.
+type Change struct {
+	Path string
+	Old  any
+	New  any
+}
+
+// ChangeSet is a list of changes made while updating a struct.
+type ChangeSet []Change
+
+// Paths returns the paths of all changes in the order they were recorded.
+func (c ChangeSet) Paths() []string {
+	paths := []string{}
+	for _, change := range c {
+		paths = append(paths, change.Path)
+	}
+	return paths
+}
+
+// Columns returns the distinct top-level keys of all changes in the order they were recorded.
+// It can be used to build a minimal column list for an SQL `UPDATE` statement.
+func (c ChangeSet) Columns() []string {
+	columns := []string{}
+	for _, change := range c {
+		column := change.Path
+		if i := strings.IndexAny(column, ".["); i >= 0 {
+			column = column[:i]
+		}
+		if !slices.Contains(columns, column) {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// Changed checks if the given path or any path below it has changed.
+func (c ChangeSet) Changed(path string) bool {
+	for _, change := range c {
+		if change.Path == path || strings.HasPrefix(change.Path, path+".") || strings.HasPrefix(change.Path, path+"[") {
+			return true
+		}
+	}
+	return false
+}
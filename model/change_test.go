@@ -0,0 +1,32 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeSet(t *testing.T) {
+	changes := ChangeSet{
+		{Path: "name", Old: "John", New: "Jane"},
+		{Path: "address.city", Old: "Berlin", New: "Hamburg"},
+		{Path: "items[0].name", Old: "apple", New: "banana"},
+		{Path: "address.zip", Old: "10115", New: "20095"},
+	}
+
+	t.Run("Paths", func(t *testing.T) {
+		assert.Equal(t, []string{"name", "address.city", "items[0].name", "address.zip"}, changes.Paths(), "Expected all paths in order")
+	})
+
+	t.Run("Columns", func(t *testing.T) {
+		assert.Equal(t, []string{"name", "address", "items"}, changes.Columns(), "Expected distinct top-level keys in order")
+	})
+
+	t.Run("Changed", func(t *testing.T) {
+		assert.True(t, changes.Changed("address"), "Expected address to be changed")
+		assert.True(t, changes.Changed("items"), "Expected items to be changed")
+		assert.True(t, changes.Changed("address.city"), "Expected address.city to be changed")
+		assert.False(t, changes.Changed("age"), "Expected age to be unchanged")
+		assert.False(t, changes.Changed("nam"), "Expected prefix of name not to be changed")
+	})
+}
@@ -21,6 +21,15 @@ const (
 	NOT_FROM     ConditionType = "nfr"
 	REGX         ConditionType = "rex"
 	FUNC         ConditionType = "fun"
+	// ENUM has no condition value, the values are declared by the type of the field (see Enum)
+	ENUM ConditionType = "enum"
+
+	// File condition types (for *multipart.FileHeader and []*multipart.FileHeader)
+	FILE_MIN_SIZE ConditionType = "fmn"
+	FILE_MAX_SIZE ConditionType = "fmx"
+	FILE_MIME     ConditionType = "mim"
+	FILE_SNIFF    ConditionType = "snf"
+	FILE_NAME     ConditionType = "fnm"
 )
 
 var ValidConditionTypes = map[ConditionType]int{
@@ -34,6 +43,25 @@ var ValidConditionTypes = map[ConditionType]int{
 	FROM:         7,
 	NOT_FROM:     8,
 	REGX:         9,
+	FUNC:         10,
+
+	FILE_MIN_SIZE: 11,
+	FILE_MAX_SIZE: 12,
+	FILE_MIME:     13,
+	FILE_SNIFF:    14,
+	FILE_NAME:     15,
+
+	ENUM: 16,
+}
+
+// ConditionTypeAcceptsList returns true for condition types with list values (eg. `frm['a', 'b c']`).
+func ConditionTypeAcceptsList(conType ConditionType) bool {
+	return conType == FROM || conType == NOT_FROM
+}
+
+// ConditionTypeHasValue returns false for condition types without condition value (eg. `enum`).
+func ConditionTypeHasValue(conType ConditionType) bool {
+	return conType != ENUM
 }
 
 // LookupConditionType checks our validConditionType map for the scanned condition type.
@@ -45,6 +73,10 @@ func LookupConditionType(conType ConditionType) error {
 	return fmt.Errorf("expected a valid condition type, found: %s", conType)
 }
 
+// ConditionValueParser parses and checks the value of a custom condition type while parsing a requirement
+// (eg. `EUR,USD` for `currencyEUR,USD`). The parsed value is set as ParsedValue of the AstValue.
+type ConditionValueParser func(value string) (any, error)
+
 // GetConditionType returns the condition type from a string.
 // It checks if the string starts with a valid condition type prefix.
 // If the string is not valid, an error is returned.
@@ -119,3 +119,15 @@ func TestGetConditionByType(t *testing.T) {
 		})
 	}
 }
+
+func TestConditionTypeHasValue(t *testing.T) {
+	assert.True(t, ConditionTypeHasValue(MIN_VALUE), "Expected min to have a value")
+	assert.True(t, ConditionTypeHasValue(FUNC), "Expected fun to have a value")
+	assert.False(t, ConditionTypeHasValue(ENUM), "Expected enum to have no value")
+}
+
+func TestConditionTypeAcceptsList(t *testing.T) {
+	assert.True(t, ConditionTypeAcceptsList(FROM), "Expected frm to accept lists")
+	assert.True(t, ConditionTypeAcceptsList(NOT_FROM), "Expected nfr to accept lists")
+	assert.False(t, ConditionTypeAcceptsList(REGX), "Expected rex to not accept lists")
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DiagnosticKind is the type for all kinds of problems found by analysing a requirement.
+type DiagnosticKind string
+
+// Available diagnostic kinds.
+const (
+	// DiagnosticUnsatisfiable is a condition which can never be fulfilled together with the other conditions (eg. `min10 && max5`).
+	DiagnosticUnsatisfiable DiagnosticKind = "unsatisfiable"
+	// DiagnosticRedundant is a condition which is always fulfilled if the other conditions are (eg. `min3` in `min3 && min5`).
+	DiagnosticRedundant DiagnosticKind = "redundant"
+	// DiagnosticInapplicable is a condition which does not apply to the type of the field (eg. `rex` on a struct).
+	DiagnosticInapplicable DiagnosticKind = "inapplicable"
+	// DiagnosticInvalidValue is a condition value which can not be used for the type of the field (eg. `equabc` on an int).
+	DiagnosticInvalidValue DiagnosticKind = "invalid value"
+)
+
+// IsError returns true for all kinds except DiagnosticRedundant, which does not change the result of a validation.
+func (k DiagnosticKind) IsError() bool {
+	return k != DiagnosticRedundant
+}
+
+// Diagnostic is a problem of a condition found by analysing a requirement.
+// Key is the key of the field (empty for a single requirement), Condition is the condition (eg. `max5`)
+// and Start and End are the position of the condition in the requirement.
+type Diagnostic struct {
+	Kind      DiagnosticKind
+	Key       string
+	Condition string
+	Message   string
+	Start     int
+	End       int
+}
+
+func (d Diagnostic) String() string {
+	if len(d.Key) > 0 {
+		return fmt.Sprintf("field %v: %v condition %v: %v", d.Key, d.Kind, d.Condition, d.Message)
+	}
+	return fmt.Sprintf("%v condition %v: %v", d.Kind, d.Condition, d.Message)
+}
+
+// Diagnostics are all problems found by analysing one or more requirements.
+type Diagnostics []Diagnostic
+
+// HasErrors checks if any of the diagnostics is an error (see DiagnosticKind.IsError).
+func (d Diagnostics) HasErrors() bool {
+	for _, diagnostic := range d {
+		if diagnostic.Kind.IsError() {
+			return true
+		}
+	}
+	return false
+}
+
+func (d Diagnostics) String() string {
+	diagnostics := []string{}
+	for _, diagnostic := range d {
+		diagnostics = append(diagnostics, diagnostic.String())
+	}
+	return strings.Join(diagnostics, "\n")
+}
@@ -0,0 +1,8 @@
+package model
+
+// Enum is implemented by enum types (eg. `type Status string` with constants) to declare their values.
+// Fields of an Enum type (or slices and arrays of Enum elements and maps with Enum keys)
+// are validated against these values with the `enum` condition.
+type Enum interface {
+	Values() []any
+}
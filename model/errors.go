@@ -0,0 +1,33 @@
+package model
+
+import "errors"
+
+// ErrUnsupportedMediaType is returned if the Content-Type of a request has no decoder.
+// It can be checked with errors.Is to answer with http.StatusUnsupportedMediaType.
+var ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+// ErrLimitExceeded is returned if a request body exceeds one of the Limits.
+// It can be checked with errors.Is to answer with http.StatusRequestEntityTooLarge.
+var ErrLimitExceeded = errors.New("limit exceeded")
+
+// ErrInvalidInput is returned if the input of a request can not be decoded or converted to the types of the struct
+// (eg. invalid json or a path parameter which is no number). It can be checked with errors.Is to answer with http.StatusBadRequest.
+var ErrInvalidInput = errors.New("invalid input")
+
+// InputError marks an error of decoding or converting the input, it matches ErrInvalidInput with errors.Is.
+type InputError struct {
+	Err error
+}
+
+func (e *InputError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *InputError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the target is ErrInvalidInput.
+func (e *InputError) Is(target error) bool {
+	return target == ErrInvalidInput
+}
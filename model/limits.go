@@ -0,0 +1,21 @@
+package model
+
+// Limits are limits for decoding request bodies.
+// A limit of 0 means unlimited.
+type Limits struct {
+	// MaxBodyBytes is the max size of the request body in bytes.
+	MaxBodyBytes int64
+	// MaxDepth is the max nesting depth of objects and arrays, the top level object has depth 1.
+	MaxDepth int
+	// MaxArrayLength is the max number of items of an array.
+	MaxArrayLength int
+	// MaxObjectKeys is the max number of keys of an object.
+	MaxObjectKeys int
+	// MaxStringLength is the max length of a string (keys included) in bytes.
+	MaxStringLength int
+}
+
+// IsUnlimited checks if no limit is set.
+func (l Limits) IsUnlimited() bool {
+	return l == Limits{}
+}
@@ -0,0 +1,12 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitsIsUnlimited(t *testing.T) {
+	assert.True(t, Limits{}.IsUnlimited(), "Expected zero limits to be unlimited")
+	assert.False(t, Limits{MaxDepth: 1}.IsUnlimited(), "Expected limits with max depth not to be unlimited")
+}
@@ -0,0 +1,35 @@
+package model
+
+import "fmt"
+
+// MergeStrategy is the type for all available strategies to merge validated values into an existing map.
+type MergeStrategy string
+
+// Available merge strategies.
+const (
+	// MergeReplace replaces the value of every validated key (default).
+	MergeReplace MergeStrategy = "replace"
+	// MergeDeep merges nested maps key by key and replaces arrays.
+	MergeDeep MergeStrategy = "deep"
+	// MergeAppendArrays merges nested maps key by key and appends arrays to the existing arrays.
+	MergeAppendArrays MergeStrategy = "append"
+	// MergeArraysByKey merges nested maps key by key and merges arrays of maps
+	// by the value of a key (eg. `id`). Items without a match are appended.
+	MergeArraysByKey MergeStrategy = "byKey"
+)
+
+var validMergeStrategies = map[MergeStrategy]int{
+	MergeReplace:      0,
+	MergeDeep:         1,
+	MergeAppendArrays: 2,
+	MergeArraysByKey:  3,
+}
+
+// LookupMergeStrategy checks our validMergeStrategies map for the given merge strategy.
+// If not found, an error is returned.
+func LookupMergeStrategy(strategy MergeStrategy) error {
+	if _, ok := validMergeStrategies[strategy]; ok {
+		return nil
+	}
+	return fmt.Errorf("expected a valid merge strategy, found: %s", strategy)
+}
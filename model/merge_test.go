@@ -0,0 +1,32 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupMergeStrategy(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   MergeStrategy
+		wantErr bool
+	}{
+		{"Replace", MergeReplace, false},
+		{"Deep", MergeDeep, false},
+		{"Append arrays", MergeAppendArrays, false},
+		{"Arrays by key", MergeArraysByKey, false},
+		{"Invalid", MergeStrategy("invalid"), true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := LookupMergeStrategy(test.input)
+			if test.wantErr {
+				assert.Error(t, err, "Expected an error for invalid merge strategy")
+			} else {
+				assert.NoError(t, err, "Expected no error for valid merge strategy")
+			}
+		})
+	}
+}
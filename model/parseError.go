@@ -0,0 +1,108 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseError is an error at a position of a requirement found while parsing.
+// Start and End are the positions of the runes (End exclusive) of the invalid part of the requirement,
+// Line and Column of the start position begin at 1.
+// Expected describes what the parser expected (eg. `condition value`) and Found is the literal found instead.
+type ParseError struct {
+	Requirement string
+	Message     string
+	Expected    string
+	Found       string
+	Line        int
+	Column      int
+	Start       int
+	End         int
+}
+
+// NewParseError creates a ParseError for the runes from start to end (exclusive) of the requirement
+// and calculates the line and column of the start position.
+func NewParseError(requirement string, start int, end int, message string) *ParseError {
+	runes := []rune(requirement)
+	start = min(max(start, 0), len(runes))
+	end = min(max(end, start), len(runes))
+
+	line := 1
+	column := 1
+	for _, char := range runes[:start] {
+		if char == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+
+	return &ParseError{
+		Requirement: requirement,
+		Message:     message,
+		Line:        line,
+		Column:      column,
+		Start:       start,
+		End:         end,
+	}
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Column, e.Message)
+}
+
+// Snippet returns the line of the requirement with the error and a line with carets (`^`) under the invalid part:
+//
+//	min10 && mux5
+//	         ^^^
+//
+// The carets end at the end of the line, at least one caret is shown (eg. for an unexpected end of the requirement).
+func (e *ParseError) Snippet() string {
+	lines := strings.Split(e.Requirement, "\n")
+	if e.Line < 1 || e.Line > len(lines) {
+		return ""
+	}
+	line := []rune(lines[e.Line-1])
+
+	column := min(e.Column-1, len(line))
+	width := max(min(e.End-e.Start, len(line)-column), 1)
+
+	// keep tabs in the indentation, so the carets are aligned
+	indent := []rune(strings.Repeat(" ", column))
+	for i, char := range line[:column] {
+		if char == '\t' {
+			indent[i] = '\t'
+		}
+	}
+
+	return fmt.Sprintf("%s\n%s%s", string(line), string(indent), strings.Repeat("^", width))
+}
+
+// ParseErrors are all errors found while parsing a requirement.
+type ParseErrors []*ParseError
+
+func (e ParseErrors) Error() string {
+	messages := []string{}
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, ", ")
+}
+
+func (e ParseErrors) Unwrap() []error {
+	errs := []error{}
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// Snippets returns the errors with their snippets (see ParseError.Snippet), separated by an empty line.
+func (e ParseErrors) Snippets() string {
+	snippets := []string{}
+	for _, err := range e {
+		snippets = append(snippets, fmt.Sprintf("%v\n%v", err.Error(), err.Snippet()))
+	}
+	return strings.Join(snippets, "\n\n")
+}
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		name            string
+		requirement     string
+		start           int
+		end             int
+		expectedLine    int
+		expectedColumn  int
+		expectedSnippet string
+	}{
+		{
+			name:            "Error in first line",
+			requirement:     "min10 && mux5",
+			start:           9,
+			end:             12,
+			expectedLine:    1,
+			expectedColumn:  10,
+			expectedSnippet: "min10 && mux5\n         ^^^",
+		},
+		{
+			name:            "Error in second line",
+			requirement:     "min1\n\t|| äqu5",
+			start:           9,
+			end:             12,
+			expectedLine:    2,
+			expectedColumn:  5,
+			expectedSnippet: "\t|| äqu5\n\t   ^^^",
+		},
+		{
+			name:            "Error at end of requirement",
+			requirement:     "(min1",
+			start:           5,
+			end:             5,
+			expectedLine:    1,
+			expectedColumn:  6,
+			expectedSnippet: "(min1\n     ^",
+		},
+		{
+			name:            "Error over multiple lines",
+			requirement:     "equ'a\nb",
+			start:           3,
+			end:             8,
+			expectedLine:    1,
+			expectedColumn:  4,
+			expectedSnippet: "equ'a\n   ^^",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := NewParseError(test.requirement, test.start, test.end, "invalid condition")
+			assert.Equal(t, test.expectedLine, err.Line, "Expected line to match")
+			assert.Equal(t, test.expectedColumn, err.Column, "Expected column to match")
+			assert.Equal(t, test.expectedSnippet, err.Snippet(), "Expected snippet to match")
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	first := NewParseError("mux1 || (min1", 0, 3, "invalid condition type")
+	second := NewParseError("mux1 || (min1", 13, 13, "expected right brace")
+	errs := ParseErrors{first, second}
+
+	assert.Equal(t, "line 1, column 1: invalid condition type, line 1, column 14: expected right brace", errs.Error(), "Expected error message to match")
+	assert.Equal(t, "line 1, column 1: invalid condition type\nmux1 || (min1\n^^^\n\nline 1, column 14: expected right brace\nmux1 || (min1\n             ^", errs.Snippets(), "Expected snippets to match")
+
+	var parseError *ParseError
+	assert.True(t, errors.As(error(errs), &parseError), "Expected errors to unwrap to a parse error")
+	assert.Equal(t, first, parseError, "Expected first parse error")
+}
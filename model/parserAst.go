@@ -2,6 +2,7 @@ package model
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -12,10 +13,16 @@ type RootNode struct {
 
 // AstValue (=abstract syntax tree value) holds a Type ("Condition" or "Group") as well as a `ConditionType` and `ConditionValue`.
 // The ConditionType is a [model.ConditionType] and the ConditionValue is any string (numbers are also represented as string).
+// ConditionArgs are the typed arguments of a function call condition like `fun:divisible(5)`
+// (int, float64, bool or string), the ConditionValue is then the name of the function.
+// For list literals like `frm['a', 'b c']` ConditionArgs are the typed items of the list.
+// ParsedValue is the ConditionValue parsed by the ConditionValueParser of a custom condition type.
 type AstValue struct {
 	Type           AstValueType
 	ConditionType  ConditionType
 	ConditionValue string
+	ConditionArgs  []any
+	ParsedValue    any
 	ConditionGroup ConditionGroup
 	Operator       Operator
 	Start          int
@@ -62,12 +69,45 @@ func (r AstValue) AstGroupToString() string {
 // If the AstValue has an Operator, it includes that in the string.
 // The resulting string is formatted as "<ConditionType>'<ConditionValue>' <Operator>" if the Operator is present,
 // or as "<ConditionType>'<ConditionValue>'" if the Operator is not present.
+// Function calls with arguments are formatted as "<ConditionType>:<ConditionValue>(<ConditionArgs>)"
+// and list literals as "<ConditionType>[<ConditionArgs>]".
 func (r AstValue) AstConditionToString() string {
+	condition := fmt.Sprintf("%v'%v'", r.ConditionType, r.ConditionValue)
+	if !ConditionTypeHasValue(r.ConditionType) {
+		condition = string(r.ConditionType)
+	} else if r.ConditionArgs != nil && r.ConditionType != FUNC {
+		condition = fmt.Sprintf("%v[%v]", r.ConditionType, ConditionArgsToString(r.ConditionArgs))
+	} else if r.ConditionArgs != nil {
+		condition = fmt.Sprintf("%v:%v(%v)", r.ConditionType, r.ConditionValue, ConditionArgsToString(r.ConditionArgs))
+	}
+
 	if len(r.Operator) > 0 {
-		return fmt.Sprintf("%v'%v' %v", r.ConditionType, r.ConditionValue, r.Operator)
+		return fmt.Sprintf("%v %v", condition, r.Operator)
 	} else {
-		return fmt.Sprintf("%v'%v'", r.ConditionType, r.ConditionValue)
+		return condition
+	}
+}
+
+// ConditionArgsToString converts function call arguments and list items to their string representation,
+// separated by `, `. Strings are quoted with `'` and contained `'` are escaped with `/`.
+func ConditionArgsToString(args []any) string {
+	argStrings := []string{}
+	for _, arg := range args {
+		switch a := arg.(type) {
+		case string:
+			argStrings = append(argStrings, "'"+strings.ReplaceAll(a, "'", "/'")+"'")
+		case float64:
+			// keep the decimal point, so the argument stays a float if it is parsed again
+			floatString := strconv.FormatFloat(a, 'f', -1, 64)
+			if !strings.Contains(floatString, ".") {
+				floatString += ".0"
+			}
+			argStrings = append(argStrings, floatString)
+		default:
+			argStrings = append(argStrings, fmt.Sprint(a))
+		}
 	}
+	return strings.Join(argStrings, ", ")
 }
 
 // Operator is the type for all available operators.
@@ -0,0 +1,175 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// rootNodeJson is the JSON representation of a RootNode.
+type rootNodeJson struct {
+	Requirement string    `json:"requirement"`
+	Root        *AstValue `json:"root"`
+}
+
+// astValueJson is the JSON representation of an AstValue.
+// ConditionArgs is a pointer to keep the difference between no argument list (`fun:check`)
+// and an empty one (`fun:check()`).
+type astValueJson struct {
+	Type           AstValueType   `json:"type"`
+	ConditionType  ConditionType  `json:"conditionType,omitempty"`
+	ConditionValue string         `json:"conditionValue,omitempty"`
+	ConditionArgs  *[]literalJson `json:"conditionArgs,omitempty"`
+	ValueSet       []string       `json:"valueSet,omitempty"`
+	ConditionGroup []*AstValue    `json:"conditionGroup,omitempty"`
+	Operator       Operator       `json:"operator,omitempty"`
+	Start          int            `json:"start"`
+	End            int            `json:"end"`
+}
+
+// literalJson is the JSON representation of a function argument or list item with its type,
+// so ints and floats (eg. `5` and `5.0`) can be told apart.
+type literalJson struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// MarshalJSON returns the canonical requirement (see RootNode.Format) together with the AST of the RootNode.
+func (r RootNode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(rootNodeJson{Requirement: r.Format(), Root: r.RootValue})
+}
+
+// UnmarshalJSON reads the AST of a RootNode written by MarshalJSON, the requirement is ignored.
+func (r *RootNode) UnmarshalJSON(data []byte) error {
+	rootNode := rootNodeJson{}
+	err := json.Unmarshal(data, &rootNode)
+	if err != nil {
+		return err
+	}
+	r.RootValue = rootNode.Root
+	return nil
+}
+
+// MarshalJSON returns the AstValue as JSON object. ConditionArgs are written with their type
+// (eg. `{"type":"int","value":5}`) and a ParsedValue of type ValueSet (eg. of `frm@countries` or `enum`) as sorted `valueSet`.
+// Other parsed values of custom condition types are not written, they are restored by parsing the requirement again.
+func (r AstValue) MarshalJSON() ([]byte, error) {
+	astValue := astValueJson{
+		Type:           r.Type,
+		ConditionType:  r.ConditionType,
+		ConditionValue: r.ConditionValue,
+		ConditionGroup: r.ConditionGroup,
+		Operator:       r.Operator,
+		Start:          r.Start,
+		End:            r.End,
+	}
+	if r.ConditionArgs != nil {
+		literals := []literalJson{}
+		for _, arg := range r.ConditionArgs {
+			literal, err := marshalLiteral(arg)
+			if err != nil {
+				return nil, err
+			}
+			literals = append(literals, literal)
+		}
+		astValue.ConditionArgs = &literals
+	}
+	if valueSet, ok := r.ParsedValue.(ValueSet); ok {
+		astValue.ValueSet = valueSet.Values()
+	}
+	return json.Marshal(astValue)
+}
+
+// UnmarshalJSON reads an AstValue written by MarshalJSON.
+func (r *AstValue) UnmarshalJSON(data []byte) error {
+	astValue := astValueJson{}
+	err := json.Unmarshal(data, &astValue)
+	if err != nil {
+		return err
+	}
+
+	*r = AstValue{
+		Type:           astValue.Type,
+		ConditionType:  astValue.ConditionType,
+		ConditionValue: astValue.ConditionValue,
+		ConditionGroup: astValue.ConditionGroup,
+		Operator:       astValue.Operator,
+		Start:          astValue.Start,
+		End:            astValue.End,
+	}
+	if astValue.ConditionArgs != nil {
+		r.ConditionArgs = []any{}
+		for _, literal := range *astValue.ConditionArgs {
+			arg, err := unmarshalLiteral(literal)
+			if err != nil {
+				return err
+			}
+			r.ConditionArgs = append(r.ConditionArgs, arg)
+		}
+	}
+	if astValue.ValueSet != nil {
+		r.ParsedValue = NewValueSet(astValue.ValueSet...)
+	}
+	return nil
+}
+
+// marshalLiteral returns the typed JSON of a function argument or list item.
+// NaN and infinite floats are written as string, because JSON has no number for them.
+func marshalLiteral(literal any) (literalJson, error) {
+	var literalType string
+	var value any = literal
+	switch l := literal.(type) {
+	case string:
+		literalType = "string"
+	case int:
+		literalType = "int"
+	case bool:
+		literalType = "bool"
+	case float64:
+		literalType = "float"
+		if math.IsNaN(l) || math.IsInf(l, 0) {
+			value = strconv.FormatFloat(l, 'f', -1, 64)
+		}
+	default:
+		return literalJson{}, fmt.Errorf("invalid literal %v of type %T", literal, literal)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return literalJson{}, err
+	}
+	return literalJson{Type: literalType, Value: data}, nil
+}
+
+// unmarshalLiteral returns the function argument or list item of the typed JSON.
+func unmarshalLiteral(literal literalJson) (any, error) {
+	var err error
+	switch literal.Type {
+	case "string":
+		var value string
+		err = json.Unmarshal(literal.Value, &value)
+		return value, err
+	case "int":
+		var value int
+		err = json.Unmarshal(literal.Value, &value)
+		return value, err
+	case "bool":
+		var value bool
+		err = json.Unmarshal(literal.Value, &value)
+		return value, err
+	case "float":
+		var value float64
+		err = json.Unmarshal(literal.Value, &value)
+		if err != nil {
+			var valueString string
+			if json.Unmarshal(literal.Value, &valueString) != nil {
+				return nil, err
+			}
+			return strconv.ParseFloat(valueString, 64)
+		}
+		return value, nil
+	default:
+		return nil, fmt.Errorf("invalid literal type %v", literal.Type)
+	}
+}
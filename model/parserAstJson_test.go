@@ -0,0 +1,64 @@
+package model
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRootNodeJson(t *testing.T) {
+	rootNode := RootNode{RootValue: &AstValue{Type: GROUP, End: 42, ConditionGroup: ConditionGroup{
+		&AstValue{Type: CONDITION, ConditionType: MIN_VALUE, ConditionValue: "2", Operator: OR, End: 4},
+		&AstValue{Type: GROUP, Start: 8, End: 42, ConditionGroup: ConditionGroup{
+			&AstValue{Type: CONDITION, ConditionType: FROM, ConditionValue: "'a', 1", ConditionArgs: []any{"a", 1}, ParsedValue: NewValueSet("a", "1"), Operator: AND, Start: 9, End: 22},
+			&AstValue{Type: CONDITION, ConditionType: FUNC, ConditionValue: "near", ConditionArgs: []any{52.5, 10.0, math.Inf(-1), false}, Start: 26, End: 41},
+		}},
+	}}}
+
+	data, err := json.Marshal(rootNode)
+	require.NoError(t, err, "Expected no error marshalling root node")
+
+	result := map[string]any{}
+	require.NoError(t, json.Unmarshal(data, &result), "Expected valid json")
+	assert.Equal(t, `min2 || (frm["a", 1] && fun:near(52.5, 10.0, -Inf, false))`, result["requirement"], "Expected canonical requirement")
+
+	unmarshalled := RootNode{}
+	err = json.Unmarshal(data, &unmarshalled)
+	require.NoError(t, err, "Expected no error unmarshalling root node")
+	assert.Equal(t, rootNode, unmarshalled, "Expected unmarshalled root node to match")
+}
+
+func TestAstValueJson(t *testing.T) {
+	t.Run("Function without argument list", func(t *testing.T) {
+		astValue := AstValue{Type: CONDITION, ConditionType: FUNC, ConditionValue: "check"}
+		data, err := json.Marshal(astValue)
+		require.NoError(t, err, "Expected no error marshalling ast value")
+		assert.JSONEq(t, `{"type":"Condition","conditionType":"fun","conditionValue":"check","start":0,"end":0}`, string(data), "Expected json to match")
+	})
+
+	t.Run("Function with empty argument list", func(t *testing.T) {
+		astValue := AstValue{Type: CONDITION, ConditionType: FUNC, ConditionValue: "check", ConditionArgs: []any{}}
+		data, err := json.Marshal(astValue)
+		require.NoError(t, err, "Expected no error marshalling ast value")
+		assert.JSONEq(t, `{"type":"Condition","conditionType":"fun","conditionValue":"check","conditionArgs":[],"start":0,"end":0}`, string(data), "Expected json to match")
+
+		unmarshalled := AstValue{}
+		require.NoError(t, json.Unmarshal(data, &unmarshalled), "Expected no error unmarshalling ast value")
+		assert.Equal(t, astValue, unmarshalled, "Expected unmarshalled ast value to match")
+	})
+
+	t.Run("Custom parsed value", func(t *testing.T) {
+		astValue := AstValue{Type: CONDITION, ConditionType: "currency", ConditionValue: "EUR", ParsedValue: []string{"EUR"}}
+		data, err := json.Marshal(astValue)
+		require.NoError(t, err, "Expected no error marshalling ast value")
+		assert.NotContains(t, string(data), "valueSet", "Expected custom parsed value to be omitted")
+	})
+
+	t.Run("Invalid literal type", func(t *testing.T) {
+		err := json.Unmarshal([]byte(`{"type":"Condition","conditionType":"fun","conditionValue":"check","conditionArgs":[{"type":"date","value":"2024"}]}`), &AstValue{})
+		assert.Error(t, err, "Expected error for invalid literal type")
+	})
+}
@@ -43,6 +43,25 @@ func TestAstGroupToString(t *testing.T) {
 			},
 			expected: "(min'2' && max'10') || equ'0'",
 		},
+		{
+			name: "Valid function call",
+			astValue: AstValue{
+				ConditionGroup: ConditionGroup{
+					&AstValue{Type: CONDITION, ConditionType: FUNC, ConditionValue: "near", ConditionArgs: []any{52.5, 10.0, 3, "it's", true}, Operator: AND},
+					&AstValue{Type: CONDITION, ConditionType: FUNC, ConditionValue: "check"},
+				},
+			},
+			expected: "fun:near(52.5, 10.0, 3, 'it/'s', true) && fun'check'",
+		},
+		{
+			name: "Valid list literal",
+			astValue: AstValue{
+				ConditionGroup: ConditionGroup{
+					&AstValue{Type: CONDITION, ConditionType: FROM, ConditionValue: "'a', 'b c', 1", ConditionArgs: []any{"a", "b c", 1}},
+				},
+			},
+			expected: "frm['a', 'b c', 1]",
+		},
 	}
 
 	for _, test := range tests {
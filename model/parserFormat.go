@@ -0,0 +1,189 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// identifierRegex matches the names of functions (eg. `checkName_2`).
+var identifierRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// IsIdentifier checks if the name can be used as function name in a function call (eg. `fun:checkName_2`).
+func IsIdentifier(name string) bool {
+	return identifierRegex.MatchString(name)
+}
+
+// Format returns the canonical requirement of the RootNode, which can be parsed again.
+// Conditions and groups are separated by explicit operators with single spaces (eg. `min1 && max5`),
+// parentheses are only kept where they change the evaluation and values are only quoted if needed.
+// Aliases are formatted as their expanded requirement.
+func (r RootNode) Format() string {
+	if r.RootValue == nil {
+		return ""
+	}
+	return r.RootValue.Format()
+}
+
+// Format returns the canonical requirement of the group or condition (see RootNode.Format).
+// The Operator of the AstValue itself is not included.
+func (r AstValue) Format() string {
+	switch r.Type {
+	case EMPTY:
+		return string(NONE)
+	case CONDITION:
+		return r.formatCondition()
+	default:
+		return strings.Join(r.unwrapGroup().formatGroupItems(), " ")
+	}
+}
+
+// unwrapGroup returns the innermost group of groups which only contain a single group (eg. `min1` for `((min1))`).
+func (r *AstValue) unwrapGroup() *AstValue {
+	group := r
+	for len(group.ConditionGroup) == 1 && group.ConditionGroup[0].Type == GROUP {
+		group = group.ConditionGroup[0]
+	}
+	return group
+}
+
+// formatGroupItems returns the formatted conditions and groups of the group with the operators between them.
+// Inner groups are inlined without parentheses if this does not change the evaluation (see canInline).
+func (r AstValue) formatGroupItems() []string {
+	items := []string{}
+	for i, v := range r.ConditionGroup {
+		switch {
+		case v.Type == GROUP && r.canInline(v.unwrapGroup()):
+			items = append(items, v.unwrapGroup().formatGroupItems()...)
+		case v.Type == GROUP:
+			items = append(items, "("+v.Format()+")")
+		default:
+			items = append(items, v.Format())
+		}
+
+		if i < len(r.ConditionGroup)-1 {
+			operator := v.Operator
+			if len(operator) == 0 {
+				operator = AND
+			}
+			items = append(items, string(operator))
+		}
+	}
+	return items
+}
+
+// canInline checks if the (unwrapped) inner group can be formatted without parentheses.
+// This is the case for groups with a single condition and for groups which only use
+// the same operator as the group they are in (eg. `min1 && (max5 && neq3)`).
+// Empty groups are always kept as `()`.
+func (r AstValue) canInline(inner *AstValue) bool {
+	if len(inner.ConditionGroup) == 0 {
+		return false
+	} else if len(inner.ConditionGroup) == 1 {
+		return true
+	}
+	operator, ok := r.groupOperator()
+	innerOperator, innerOk := inner.groupOperator()
+	return ok && innerOk && operator == innerOperator
+}
+
+// groupOperator returns the operator connecting all conditions and groups of the group,
+// it returns false if the group mixes `&&` and `||`.
+func (r AstValue) groupOperator() (Operator, bool) {
+	var groupOperator Operator
+	for i, v := range r.ConditionGroup {
+		if i == len(r.ConditionGroup)-1 {
+			break
+		}
+		operator := v.Operator
+		if len(operator) == 0 {
+			operator = AND
+		}
+		if len(groupOperator) > 0 && operator != groupOperator {
+			return "", false
+		}
+		groupOperator = operator
+	}
+	if len(groupOperator) == 0 {
+		groupOperator = AND
+	}
+	return groupOperator, true
+}
+
+// formatCondition returns the canonical condition, eg. `min5`, `equ"a b"`, `frm["a", 1]` or `fun:near(52.5, 10)`.
+func (r AstValue) formatCondition() string {
+	switch {
+	case !ConditionTypeHasValue(r.ConditionType):
+		return string(r.ConditionType)
+	case r.ConditionType == FUNC && IsIdentifier(r.ConditionValue) && r.ConditionArgs != nil:
+		return fmt.Sprintf("%v:%v(%v)", r.ConditionType, r.ConditionValue, FormatLiterals(r.ConditionArgs))
+	case r.ConditionType == FUNC && IsIdentifier(r.ConditionValue):
+		return fmt.Sprintf("%v:%v", r.ConditionType, r.ConditionValue)
+	case ConditionTypeAcceptsList(r.ConditionType) && r.ConditionArgs != nil:
+		return fmt.Sprintf("%v[%v]", r.ConditionType, FormatLiterals(r.ConditionArgs))
+	default:
+		return string(r.ConditionType) + FormatValue(r.ConditionType, r.ConditionValue)
+	}
+}
+
+// FormatValue returns the condition value of the condition type as it is
+// or in `"` with the escapes of Go strings if it can not be parsed as it is
+// (eg. for values with whitespace, unbalanced braces or a leading quote or operator).
+func FormatValue(conType ConditionType, value string) string {
+	if needsQuotes(conType, value) {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+func needsQuotes(conType ConditionType, value string) bool {
+	if len(value) == 0 || strings.ContainsAny(value, " \t\n\r\x00") {
+		return true
+	}
+	switch value[0] {
+	case '\'', '"', ':', '(', ')', '|', '&':
+		return true
+	case '[':
+		if ConditionTypeAcceptsList(conType) {
+			return true
+		}
+	}
+
+	// the value ends at an unbalanced `)`, an unclosed `(` would take the `)` of the group
+	depth := 0
+	for _, char := range value {
+		if char == '(' {
+			depth++
+		} else if char == ')' {
+			depth--
+		}
+		if depth < 0 {
+			return true
+		}
+	}
+	return depth != 0
+}
+
+// FormatLiterals returns the function arguments or list items separated by `, `, so they can be parsed again.
+// Strings are quoted with `"` and the escapes of Go strings and floats always have a decimal point (eg. `10.0`).
+func FormatLiterals(literals []any) string {
+	literalStrings := []string{}
+	for _, literal := range literals {
+		switch l := literal.(type) {
+		case string:
+			literalStrings = append(literalStrings, strconv.Quote(l))
+		case float64:
+			floatString := strconv.FormatFloat(l, 'f', -1, 64)
+			// keep the decimal point, so the literal stays a float if it is parsed again
+			if !strings.Contains(floatString, ".") && !math.IsInf(l, 0) && !math.IsNaN(l) {
+				floatString += ".0"
+			}
+			literalStrings = append(literalStrings, floatString)
+		default:
+			literalStrings = append(literalStrings, fmt.Sprint(l))
+		}
+	}
+	return strings.Join(literalStrings, ", ")
+}
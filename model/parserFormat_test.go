@@ -0,0 +1,97 @@
+package model
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		astValue AstValue
+		expected string
+	}{
+		{
+			name:     "Empty requirement",
+			astValue: AstValue{Type: EMPTY},
+			expected: "-",
+		},
+		{
+			name: "Conditions with implicit and trailing operators",
+			astValue: AstValue{Type: GROUP, ConditionGroup: ConditionGroup{
+				&AstValue{Type: CONDITION, ConditionType: MIN_VALUE, ConditionValue: "2"},
+				&AstValue{Type: CONDITION, ConditionType: MAX_VALUE, ConditionValue: "10", Operator: OR},
+			}},
+			expected: "min2 && max10",
+		},
+		{
+			name: "Group with single condition",
+			astValue: AstValue{Type: GROUP, ConditionGroup: ConditionGroup{
+				&AstValue{Type: GROUP, Operator: OR, ConditionGroup: ConditionGroup{
+					&AstValue{Type: CONDITION, ConditionType: EQUAL, ConditionValue: "a"},
+				}},
+				&AstValue{Type: CONDITION, ConditionType: EQUAL, ConditionValue: "b"},
+			}},
+			expected: "equa || equb",
+		},
+		{
+			name: "Group with same operator",
+			astValue: AstValue{Type: GROUP, ConditionGroup: ConditionGroup{
+				&AstValue{Type: CONDITION, ConditionType: EQUAL, ConditionValue: "a", Operator: OR},
+				&AstValue{Type: GROUP, ConditionGroup: ConditionGroup{
+					&AstValue{Type: CONDITION, ConditionType: EQUAL, ConditionValue: "b", Operator: OR},
+					&AstValue{Type: CONDITION, ConditionType: EQUAL, ConditionValue: "c"},
+				}},
+			}},
+			expected: "equa || equb || equc",
+		},
+		{
+			name: "Group with other operator",
+			astValue: AstValue{Type: GROUP, ConditionGroup: ConditionGroup{
+				&AstValue{Type: GROUP, Operator: AND, ConditionGroup: ConditionGroup{
+					&AstValue{Type: CONDITION, ConditionType: EQUAL, ConditionValue: "a", Operator: OR},
+					&AstValue{Type: CONDITION, ConditionType: EQUAL, ConditionValue: "b"},
+				}},
+				&AstValue{Type: GROUP, ConditionGroup: ConditionGroup{}},
+			}},
+			expected: "(equa || equb) && ()",
+		},
+		{
+			name: "Conditions with quoted values",
+			astValue: AstValue{Type: GROUP, ConditionGroup: ConditionGroup{
+				&AstValue{Type: CONDITION, ConditionType: EQUAL, ConditionValue: "a b", Operator: AND},
+				&AstValue{Type: CONDITION, ConditionType: NOT_EQUAL, ConditionValue: "", Operator: AND},
+				&AstValue{Type: CONDITION, ConditionType: REGX, ConditionValue: "^(a|b$", Operator: AND},
+				&AstValue{Type: CONDITION, ConditionType: CONTAINS, ConditionValue: "'a'", Operator: AND},
+				&AstValue{Type: CONDITION, ConditionType: FROM, ConditionValue: "[a]"},
+			}},
+			expected: `equ"a b" && neq"" && rex"^(a|b$" && con"'a'" && frm"[a]"`,
+		},
+		{
+			name: "Conditions with lists, functions and enum",
+			astValue: AstValue{Type: GROUP, ConditionGroup: ConditionGroup{
+				&AstValue{Type: CONDITION, ConditionType: FROM, ConditionValue: "'a', 1", ConditionArgs: []any{"a", 1}, Operator: AND},
+				&AstValue{Type: CONDITION, ConditionType: FUNC, ConditionValue: "near", ConditionArgs: []any{52.5, 10.0, "km"}, Operator: AND},
+				&AstValue{Type: CONDITION, ConditionType: FUNC, ConditionValue: "check", ConditionArgs: []any{}, Operator: AND},
+				&AstValue{Type: CONDITION, ConditionType: FUNC, ConditionValue: "check", Operator: AND},
+				&AstValue{Type: CONDITION, ConditionType: FUNC, ConditionValue: "my-check", Operator: AND},
+				&AstValue{Type: CONDITION, ConditionType: ENUM},
+			}},
+			expected: `frm["a", 1] && fun:near(52.5, 10.0, "km") && fun:check() && fun:check && funmy-check && enum`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.astValue.Format(), "Expected formatted requirement to match")
+			assert.Equal(t, test.expected, RootNode{RootValue: &test.astValue}.Format(), "Expected formatted root node to match")
+		})
+	}
+}
+
+func TestFormatLiterals(t *testing.T) {
+	literals := []any{"a \"b\"", 1, -2.5, 3.0, math.Inf(1), math.NaN(), true}
+	assert.Equal(t, `"a \"b\"", 1, -2.5, 3.0, +Inf, NaN, true`, FormatLiterals(literals), "Expected formatted literals to match")
+}
@@ -2,7 +2,7 @@ package model
 
 // Token represents a token in the parser.
 // It contains the type of the token, its literal value, and its position in the requirement.
-// The position is defined by the line number, start index, and end index.
+// The position is defined by the line number, start index, and end index (exclusive) of the runes in the requirement.
 // This structure is used to tokenize the input for parsing and validation.
 type Token struct {
 	Type    TokenType
@@ -33,6 +33,9 @@ const (
 	LexerConditionType        TokenType = "CONDITION_TYPE"
 	LexerConditionValue       TokenType = "CONDITION_VALUE"
 	LexerConditionValueString TokenType = "CONDITION_VALUE_STRING"
+	LexerConditionValueList   TokenType = "CONDITION_VALUE_LIST"
+	LexerFunctionCall         TokenType = "FUNCTION_CALL"
+	LexerAlias                TokenType = "ALIAS"
 
 	// Operators
 	LexerOperator TokenType = "OPERATOR"
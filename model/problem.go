@@ -0,0 +1,100 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Problem is a problem details document (RFC 9457) with an `errors` extension
+// listing every invalid field.
+type Problem struct {
+	Type     string         `json:"type,omitempty"`
+	Title    string         `json:"title,omitempty"`
+	Status   int            `json:"status,omitempty"`
+	Detail   string         `json:"detail,omitempty"`
+	Instance string         `json:"instance,omitempty"`
+	Errors   []ProblemError `json:"errors,omitempty"`
+}
+
+// ProblemError is a single failure of a Problem.
+// Field is the path of the field (eg. `address.city` or `items[0].name`), it is empty for groups.
+// Rule is the condition that failed (eg. `min3`, `required` for missing keys or `gr1min1` for groups).
+type ProblemError struct {
+	Field   string `json:"field,omitempty"`
+	Rule    string `json:"rule,omitempty"`
+	Message string `json:"message"`
+}
+
+// ProblemErrorsFromError returns a ProblemError for every ValidationError and GroupError in the error.
+// It returns an empty slice if the error is no validation error (see IsValidationError).
+func ProblemErrorsFromError(err error) []ProblemError {
+	return appendProblemErrors([]ProblemError{}, "", err)
+}
+
+func appendProblemErrors(problemErrors []ProblemError, path string, err error) []ProblemError {
+	for err != nil {
+		switch e := err.(type) {
+		case *ValidationError:
+			fieldPath := e.Key
+			if len(path) > 0 {
+				fieldPath = path + "." + e.Key
+			}
+			if e.Index != nil {
+				fieldPath = fmt.Sprintf("%v[%d]", fieldPath, *e.Index)
+			}
+
+			if e.Missing {
+				return append(problemErrors, ProblemError{Field: fieldPath, Rule: "required", Message: messageFromValidationError(e)})
+			} else if IsValidationError(e.Err) {
+				return appendProblemErrors(problemErrors, fieldPath, e.Err)
+			}
+			return append(problemErrors, ProblemError{Field: fieldPath, Rule: ruleFromError(e.Err), Message: messageFromValidationError(e)})
+		case *GroupError:
+			problemErrors = append(problemErrors, ProblemError{
+				Rule:    fmt.Sprintf("%v%v%v", e.Group.Name, e.Group.ConditionType, e.Group.ConditionValue),
+				Message: e.Message(),
+			})
+			for _, groupErr := range e.Errors {
+				problemErrors = appendProblemErrors(problemErrors, path, groupErr)
+			}
+			return problemErrors
+		}
+		err = errors.Unwrap(err)
+	}
+	return problemErrors
+}
+
+// messageFromValidationError returns the custom message of the ValidationError or the message of its error.
+// For a failed group with OR it is the description of the group if available (eg. `must be empty, or exactly 'Test'`).
+func messageFromValidationError(e *ValidationError) string {
+	if len(e.Message) > 0 {
+		return e.Message
+	} else if e.Missing {
+		return e.Error()
+	} else if groupErr, ok := e.Err.(*ConditionGroupError); ok && len(groupErr.Description) > 0 {
+		return groupErr.Message()
+	}
+	return e.Err.Error()
+}
+
+// ruleFromError returns the condition of a ConditionError (eg. `min3`) or the conditions
+// of a ConditionGroupError connected with OR (eg. `max0 || min10`).
+func ruleFromError(err error) string {
+	for err != nil {
+		switch e := err.(type) {
+		case *ConditionError:
+			return fmt.Sprintf("%v%v", e.ConditionType, e.ConditionValue)
+		case *ConditionGroupError:
+			rules := []string{}
+			for _, conditionErr := range e.Errors {
+				if rule := ruleFromError(conditionErr); len(rule) > 0 {
+					rules = append(rules, rule)
+				}
+			}
+			return strings.Join(rules, " || ")
+		}
+		err = errors.Unwrap(err)
+	}
+	return ""
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProblemErrorsFromError(t *testing.T) {
+	index := 1
+	minErr := &ConditionError{ConditionType: MIN_VALUE, ConditionValue: "3", Err: errors.New("value less than minimum condition 3")}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected []ProblemError
+	}{
+		{
+			name:     "Field with condition",
+			err:      fmt.Errorf("error validating struct: %w", &ValidationError{Key: "name", Err: minErr}),
+			expected: []ProblemError{{Field: "name", Rule: "min3", Message: "value less than minimum condition 3"}},
+		},
+		{
+			name:     "Missing field",
+			err:      &ValidationError{Key: "name", Missing: true},
+			expected: []ProblemError{{Field: "name", Rule: "required", Message: "json name key not in map"}},
+		},
+		{
+			name:     "Field with custom message",
+			err:      &ValidationError{Key: "name", Message: "name is too short", Err: minErr},
+			expected: []ProblemError{{Field: "name", Rule: "min3", Message: "name is too short"}},
+		},
+		{
+			name:     "Missing field with custom message",
+			err:      &ValidationError{Key: "name", Missing: true, Message: "name is required"},
+			expected: []ProblemError{{Field: "name", Rule: "required", Message: "name is required"}},
+		},
+		{
+			name:     "Nested field in array",
+			err:      &ValidationError{Key: "items", Index: &index, Err: &ValidationError{Key: "address", Err: &ValidationError{Key: "city", Err: minErr}}},
+			expected: []ProblemError{{Field: "items[1].address.city", Rule: "min3", Message: "value less than minimum condition 3"}},
+		},
+		{
+			name: "Field with or group",
+			err: &ValidationError{Key: "name", Err: &ConditionGroupError{Errors: []error{
+				&ConditionError{ConditionType: MAX_VALUE, ConditionValue: "0", Err: errors.New("value greater than maximum condition 0")},
+				minErr,
+			}}},
+			expected: []ProblemError{{
+				Field:   "name",
+				Rule:    "max0 || min3",
+				Message: "no condition fulfilled, all errors: [value greater than maximum condition 0 value less than minimum condition 3]",
+			}},
+		},
+		{
+			name: "Group",
+			err: &GroupError{Group: &Group{Name: "gr1", ConditionType: MIN_VALUE, ConditionValue: "1"}, Errors: []error{
+				&ValidationError{Key: "name", Missing: true},
+				&ValidationError{Key: "age", Err: minErr},
+			}},
+			expected: []ProblemError{
+				{Rule: "gr1min1", Message: "less then 1 in group gr1 without error"},
+				{Field: "name", Rule: "required", Message: "json name key not in map"},
+				{Field: "age", Rule: "min3", Message: "value less than minimum condition 3"},
+			},
+		},
+		{
+			name:     "No validation error",
+			err:      errors.New("duplicate validation key: name"),
+			expected: []ProblemError{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ProblemErrorsFromError(test.err), "Expected problem errors to match")
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tag type of the request source of a struct field (eg. `src:"path"` or `src:"header:X-Request-ID"`).
+const SRC string = "src"
+
+// Source is the part of a request a value is bound from.
+// An empty Source means the value is bound from the request body.
+type Source string
+
+const (
+	SourceBody   Source = ""
+	SourcePath   Source = "path"
+	SourceQuery  Source = "query"
+	SourceHeader Source = "header"
+	SourceCookie Source = "cookie"
+)
+
+// GetSource parses a source tag into the source and the name of the value in the source.
+// The name is optional and separated by a colon (eg. `header:X-Request-ID`), if it is empty
+// the key of the field should be used.
+// If the source is not recognized, an error is returned.
+func GetSource(s string) (Source, string, error) {
+	sourceString, name, _ := strings.Cut(strings.TrimSpace(s), ":")
+	source := Source(strings.ToLower(strings.TrimSpace(sourceString)))
+	switch source {
+	case SourceBody, SourcePath, SourceQuery, SourceHeader, SourceCookie:
+		return source, strings.TrimSpace(name), nil
+	default:
+		return SourceBody, "", fmt.Errorf("invalid source: %s", sourceString)
+	}
+}
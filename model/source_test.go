@@ -0,0 +1,37 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSource(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		expected     Source
+		expectedName string
+		wantErr      bool
+	}{
+		{"Empty", "", SourceBody, "", false},
+		{"Path", "path", SourcePath, "", false},
+		{"Query with name", "query:page_size", SourceQuery, "page_size", false},
+		{"Header with name", "Header:X-Request-ID", SourceHeader, "X-Request-ID", false},
+		{"Cookie", "cookie", SourceCookie, "", false},
+		{"Invalid", "body", SourceBody, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			source, name, err := GetSource(test.input)
+			if test.wantErr {
+				assert.Error(t, err, "Expected error for invalid source")
+			} else {
+				assert.NoError(t, err, "Expected no error for valid source")
+				assert.Equal(t, test.expected, source, "Expected source to match")
+				assert.Equal(t, test.expectedName, name, "Expected name to match")
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Names of the sections of a validation tag with named sections
+// (eg. `req=min3; groups=gr1min1; default=guest; msg=name is too short`).
+const (
+	TagRequirement = "req"
+	TagGroups      = "groups"
+	TagDefault     = "default"
+	TagMessage     = "msg"
+)
+
+// tagGroupsRegex matches parts of a tag with the shape of groups with numeric values (eg. `gr1min1 gr2max1`).
+var tagGroupsRegex = regexp.MustCompile(`^[a-z][a-z0-9]{2}[a-z]{3}[0-9]+( [a-z][a-z0-9]{2}[a-z]{3}[0-9]+)*$`)
+
+// tagSectionRegex matches the start of a named section, at the beginning of the tag or after a `;`.
+var tagSectionRegex = regexp.MustCompile(`(?:^|;)\s*(req|groups|default|msg)=`)
+
+// Tag is a parsed validation tag.
+type Tag struct {
+	Requirement string
+	Groups      []*Group
+	Default     string
+	Message     string
+}
+
+// ParseTag parses a validation tag. It returns nil if the tag is empty.
+//
+// Tags starting with a named section (`req=`, `groups=`, `default=` or `msg=`) are split into their sections,
+// a section ends at the next `;` followed by a section name, so requirements can contain `;` and `, `.
+// The `req` section is required, every section can only be given once.
+//
+// All other tags are handled in the short form `<requirement>, <groups>`. The part after the last `, `
+// is only used as groups if the whole part parses as groups with numeric values (eg. `gr1min1 gr2max1`),
+// otherwise it is part of the requirement (eg. in `rex^[a-z]+, [a-z]+$` or `equ'a, b'`).
+// A part with the shape of groups which can not be parsed returns an error (eg. `gp1min1`),
+// tags which are ambiguous in the short form can use the `req=` and `groups=` sections.
+func ParseTag(tag string) (*Tag, error) {
+	if len(strings.TrimSpace(tag)) == 0 {
+		return nil, nil
+	}
+
+	if match := tagSectionRegex.FindStringIndex(tag); match != nil && match[0] == 0 {
+		return parseNamedTag(tag)
+	}
+
+	parsedTag := &Tag{Requirement: tag}
+	if index := strings.LastIndex(tag, ", "); index >= 0 {
+		groupsPart := tag[index+2:]
+		groups, err := parseShortTagGroups(groupsPart)
+		if err == nil {
+			parsedTag.Requirement = tag[:index]
+			parsedTag.Groups = groups
+		} else if tagGroupsRegex.MatchString(groupsPart) {
+			return nil, fmt.Errorf("error parsing groups: %v (use the sections %s= and %s= if %s is part of the requirement)", err, TagRequirement, TagGroups, groupsPart)
+		}
+	}
+	if len(strings.TrimSpace(parsedTag.Requirement)) == 0 {
+		return nil, fmt.Errorf("empty requirement in tag: %s", tag)
+	}
+	return parsedTag, nil
+}
+
+// parseNamedTag parses a tag with named sections.
+func parseNamedTag(tag string) (*Tag, error) {
+	sections := map[string]string{}
+	matches := tagSectionRegex.FindAllStringSubmatchIndex(tag, -1)
+	for i, match := range matches {
+		name := tag[match[2]:match[3]]
+		end := len(tag)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		if _, ok := sections[name]; ok {
+			return nil, fmt.Errorf("duplicate tag section: %s", name)
+		}
+		sections[name] = strings.TrimSpace(tag[match[1]:end])
+	}
+
+	requirement, ok := sections[TagRequirement]
+	if !ok || len(requirement) == 0 {
+		return nil, fmt.Errorf("missing tag section: %s", TagRequirement)
+	}
+
+	parsedTag := &Tag{
+		Requirement: requirement,
+		Default:     sections[TagDefault],
+		Message:     sections[TagMessage],
+	}
+	if groups := sections[TagGroups]; len(groups) > 0 {
+		var err error
+		parsedTag.Groups, err = GetGroups(groups)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing groups: %v", err)
+		}
+	}
+	return parsedTag, nil
+}
+
+// parseShortTagGroups parses the last part of a tag in the short form as groups.
+// It returns an error if the part is no list of groups with numeric values.
+func parseShortTagGroups(part string) ([]*Group, error) {
+	groups, err := GetGroups(part)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		if _, err := strconv.Atoi(group.ConditionValue); err != nil {
+			return nil, fmt.Errorf("invalid value %s of group %s, expected a number", group.ConditionValue, group.Name)
+		}
+	}
+	return groups, nil
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTag(t *testing.T) {
+	group1 := &Group{Name: "gr1", ConditionType: MIN_VALUE, ConditionValue: "1"}
+	group2 := &Group{Name: "gr2", ConditionType: MAX_VALUE, ConditionValue: "2"}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected *Tag
+		wantErr  bool
+	}{
+		{name: "Empty tag", input: "", expected: nil},
+		{name: "Requirement", input: "min3", expected: &Tag{Requirement: "min3"}},
+		{name: "Short requirement", input: "min", expected: &Tag{Requirement: "min"}},
+		{name: "No validation", input: "-", expected: &Tag{Requirement: "-"}},
+		{name: "Requirement and groups", input: "min3, gr1min1 gr2max2", expected: &Tag{Requirement: "min3", Groups: []*Group{group1, group2}}},
+		{name: "Regex with comma", input: "rex^[a-z]+, [a-z]+$", expected: &Tag{Requirement: "rex^[a-z]+, [a-z]+$"}},
+		{name: "Regex with comma and groups", input: "rex^[a-z]+, [a-z]+$, gr1min1", expected: &Tag{Requirement: "rex^[a-z]+, [a-z]+$", Groups: []*Group{group1}}},
+		{name: "String with comma", input: "equ'a, gr1min1'", expected: &Tag{Requirement: "equ'a, gr1min1'"}},
+		{name: "Function arguments", input: "fun:near(52.5, 13.4, 10), gr1min1", expected: &Tag{Requirement: "fun:near(52.5, 13.4, 10)", Groups: []*Group{group1}}},
+		{
+			name:     "Named sections",
+			input:    "req=min3; groups=gr1min1; default=guest; msg=name is too short",
+			expected: &Tag{Requirement: "min3", Groups: []*Group{group1}, Default: "guest", Message: "name is too short"},
+		},
+		{
+			name:     "Named sections in other order with separators in requirement",
+			input:    "msg=invalid code;req=rex^[a-z]{2};[0-9]+, x$ ; default=ab;12",
+			expected: &Tag{Requirement: "rex^[a-z]{2};[0-9]+, x$", Default: "ab;12", Message: "invalid code"},
+		},
+		{name: "Named short requirement", input: "req=-", expected: &Tag{Requirement: "-"}},
+		{name: "Regex with unbalanced brace and groups", input: "rex^[(]+, gr1min1", expected: &Tag{Requirement: "rex^[(]+", Groups: []*Group{group1}}},
+		{name: "Regex with comma and part starting with gr", input: "rex^a, grape$", expected: &Tag{Requirement: "rex^a, grape$"}},
+		{name: "Double quoted string with comma", input: `equ"a, gr1min1"`, expected: &Tag{Requirement: `equ"a, gr1min1"`}},
+		{name: "Incomplete groups as requirement", input: "min3, gr", expected: &Tag{Requirement: "min3, gr"}},
+		{name: "Invalid group name", input: "min3, gp1min1", wantErr: true},
+		{name: "Invalid group condition", input: "min3, gr1mux1", wantErr: true},
+		{name: "Invalid empty requirement", input: ", gr1min1", wantErr: true},
+		{name: "Invalid named groups", input: "req=min3; groups=gp1min1", wantErr: true},
+		{name: "Invalid missing requirement", input: "groups=gr1min1; msg=invalid", wantErr: true},
+		{name: "Invalid empty requirement section", input: "req=; msg=invalid", wantErr: true},
+		{name: "Invalid duplicate section", input: "req=min3; req=max5", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tag, err := ParseTag(test.input)
+			if test.wantErr {
+				assert.Error(t, err, "Expected error for tag: %s", test.input)
+			} else {
+				assert.NoError(t, err, "Expected no error for tag: %s", test.input)
+				assert.Equal(t, test.expected, tag, "Expected parsed tag to match")
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TraceOutcome is the type for all outcomes of a condition or group in a Trace.
+type TraceOutcome string
+
+// Available trace outcomes.
+const (
+	// TracePassed is a condition or group which was fulfilled.
+	TracePassed TraceOutcome = "passed"
+	// TraceFailed is a condition or group which was not fulfilled.
+	TraceFailed TraceOutcome = "failed"
+	// TraceSkipped is a condition or group which was not evaluated, because a condition or group
+	// connected with AND before it failed.
+	TraceSkipped TraceOutcome = "skipped"
+)
+
+// Trace is the evaluation of a requirement for an input, it mirrors the AST of the requirement.
+// The root trace has the Key of the field (if any), the canonical Requirement and the Input,
+// its Children are the traces of the conditions and groups of the requirement.
+// Condition is the canonical condition (eg. `min3`) and Operator the operator after the condition or group.
+// Err is the error of a failed condition or group (eg. a ConditionError or ConditionGroupError).
+type Trace struct {
+	Key         string
+	Requirement string
+	Input       any
+	Type        AstValueType
+	Condition   string
+	Operator    Operator
+	Outcome     TraceOutcome
+	Err         error
+	Children    []*Trace
+	Start       int
+	End         int
+}
+
+// NewTrace creates a trace of the condition or group without outcome.
+func NewTrace(astValue *AstValue) *Trace {
+	trace := &Trace{
+		Type:     astValue.Type,
+		Operator: astValue.Operator,
+		Start:    astValue.Start,
+		End:      astValue.End,
+	}
+	if astValue.Type == CONDITION {
+		trace.Condition = astValue.Format()
+	}
+	return trace
+}
+
+// NewRootTrace creates the trace of a requirement for the input without outcome.
+func NewRootTrace(key string, rootNode RootNode, input any) *Trace {
+	trace := NewTrace(rootNode.RootValue)
+	trace.Key = key
+	trace.Requirement = rootNode.Format()
+	trace.Input = input
+	return trace
+}
+
+// SetOutcome sets the outcome of the trace to TracePassed or to TraceFailed with the error.
+// It does nothing for a nil trace, so validations without trace do not need to check for it.
+func (t *Trace) SetOutcome(err error) {
+	if t == nil {
+		return
+	}
+	t.Outcome = TracePassed
+	if err != nil {
+		t.Outcome = TraceFailed
+		t.Err = err
+	}
+}
+
+// AddSkipped adds skipped traces of the conditions and groups (and their inner conditions and groups) to the children.
+// It does nothing for a nil trace.
+func (t *Trace) AddSkipped(astValues []*AstValue) {
+	if t == nil {
+		return
+	}
+	for _, astValue := range astValues {
+		trace := NewTrace(astValue)
+		trace.Outcome = TraceSkipped
+		trace.AddSkipped(astValue.ConditionGroup)
+		t.Children = append(t.Children, trace)
+	}
+}
+
+// Conditions returns the traces of all conditions in the order of the requirement.
+func (t *Trace) Conditions() []*Trace {
+	conditions := []*Trace{}
+	for _, child := range t.Children {
+		if child.Type == CONDITION {
+			conditions = append(conditions, child)
+		} else {
+			conditions = append(conditions, child.Conditions()...)
+		}
+	}
+	return conditions
+}
+
+// String returns the trace as indented text with one line per condition and group, eg.:
+//
+//	name: max0 || min3 with input "ab": failed: must be empty, or at least 3 characters long
+//	  failed max0 ||: value greater than maximum condition 0
+//	  failed min3: value less than minimum condition 3
+func (t *Trace) String() string {
+	lines := []string{}
+	header := fmt.Sprintf("%v with input %v: %v", t.Requirement, formatTraceInput(t.Input), t.Outcome)
+	if len(t.Key) > 0 {
+		header = fmt.Sprintf("%v: %v", t.Key, header)
+	}
+	if t.Err != nil {
+		header = fmt.Sprintf("%v: %v", header, traceErrorMessage(t.Err))
+	}
+	lines = append(lines, header)
+	lines = t.appendChildLines(lines, 1)
+	return strings.Join(lines, "\n")
+}
+
+func (t *Trace) appendChildLines(lines []string, depth int) []string {
+	for i, child := range t.Children {
+		line := fmt.Sprintf("%v%v %v", strings.Repeat("  ", depth), child.Outcome, child.Condition)
+		if child.Type == GROUP {
+			line = fmt.Sprintf("%v%v group", strings.Repeat("  ", depth), child.Outcome)
+		}
+		if len(child.Operator) > 0 && i < len(t.Children)-1 {
+			line = fmt.Sprintf("%v %v", line, child.Operator)
+		}
+		if child.Err != nil {
+			line = fmt.Sprintf("%v: %v", line, traceErrorMessage(child.Err))
+		}
+		lines = append(lines, line)
+		lines = child.appendChildLines(lines, depth+1)
+	}
+	return lines
+}
+
+// traceErrorMessage returns the message of the error without the errors of the conditions
+// of a ConditionGroupError, they are part of the inner traces.
+func traceErrorMessage(err error) string {
+	if groupErr, ok := err.(*ConditionGroupError); ok {
+		return groupErr.Message()
+	}
+	return err.Error()
+}
+
+// formatTraceInput returns strings quoted and all other inputs as they are.
+func formatTraceInput(input any) string {
+	if inputString, ok := input.(string); ok {
+		return strconv.Quote(inputString)
+	}
+	return fmt.Sprintf("%v", input)
+}
+
+// traceJson is the JSON representation of a Trace.
+type traceJson struct {
+	Key         string       `json:"key,omitempty"`
+	Requirement string       `json:"requirement,omitempty"`
+	Input       any          `json:"input,omitempty"`
+	Type        AstValueType `json:"type"`
+	Condition   string       `json:"condition,omitempty"`
+	Operator    Operator     `json:"operator,omitempty"`
+	Outcome     TraceOutcome `json:"outcome"`
+	Error       string       `json:"error,omitempty"`
+	Children    []*Trace     `json:"children,omitempty"`
+	Start       int          `json:"start"`
+	End         int          `json:"end"`
+}
+
+// MarshalJSON returns the trace as JSON object with the message of the error as `error` (see String).
+func (t *Trace) MarshalJSON() ([]byte, error) {
+	trace := traceJson{
+		Key:         t.Key,
+		Requirement: t.Requirement,
+		Input:       t.Input,
+		Type:        t.Type,
+		Condition:   t.Condition,
+		Operator:    t.Operator,
+		Outcome:     t.Outcome,
+		Children:    t.Children,
+		Start:       t.Start,
+		End:         t.End,
+	}
+	if t.Err != nil {
+		trace.Error = traceErrorMessage(t.Err)
+	}
+	return json.Marshal(trace)
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrace(t *testing.T) {
+	rootNode := RootNode{RootValue: &AstValue{Type: GROUP, ConditionGroup: ConditionGroup{
+		&AstValue{Type: CONDITION, ConditionType: MIN_VALUE, ConditionValue: "3", Operator: AND},
+		&AstValue{Type: GROUP, ConditionGroup: ConditionGroup{
+			&AstValue{Type: CONDITION, ConditionType: EQUAL, ConditionValue: "a b", Operator: OR},
+			&AstValue{Type: CONDITION, ConditionType: MAX_VALUE, ConditionValue: "5"},
+		}},
+	}}}
+
+	trace := NewRootTrace("", rootNode, 2)
+	minTrace := NewTrace(rootNode.RootValue.ConditionGroup[0])
+	minErr := &ConditionError{ConditionType: MIN_VALUE, ConditionValue: "3", Err: errors.New("value less than minimum condition 3")}
+	minTrace.SetOutcome(minErr)
+	trace.Children = append(trace.Children, minTrace)
+	trace.AddSkipped(rootNode.RootValue.ConditionGroup[1:])
+	trace.SetOutcome(minErr)
+
+	conditions := trace.Conditions()
+	require.Len(t, conditions, 3, "Expected traces of all conditions")
+	assert.Equal(t, `equ"a b"`, conditions[1].Condition, "Expected canonical condition")
+	assert.Equal(t, TraceSkipped, conditions[2].Outcome, "Expected skipped condition in skipped group")
+
+	assert.Equal(t, `min3 && (equ"a b" || max5) with input 2: failed: value less than minimum condition 3
+  failed min3 &&: value less than minimum condition 3
+  skipped group
+    skipped equ"a b" ||
+    skipped max5`, trace.String(), "Expected text of trace to match")
+
+	data, err := json.Marshal(trace)
+	require.NoError(t, err, "Expected no error marshalling trace")
+	assert.Contains(t, string(data), `"outcome":"skipped"`, "Expected skipped outcome in json")
+	assert.Contains(t, string(data), `"error":"value less than minimum condition 3"`, "Expected error message in json")
+}
+
+func TestTraceNil(t *testing.T) {
+	var trace *Trace
+	assert.NotPanics(t, func() {
+		trace.SetOutcome(errors.New("error"))
+		trace.AddSkipped(ConditionGroup{&AstValue{Type: CONDITION, ConditionType: MIN_VALUE, ConditionValue: "1"}})
+	}, "Expected nil trace to be ignored")
+}
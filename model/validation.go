@@ -9,7 +9,15 @@ type Validation struct {
 	Type        ValidatorType
 	Requirement string
 	Groups      []*Group
-	Default     string
+	// Default is used as form value if the key is missing (eg. `guest`)
+	Default string
+	// Message replaces the error message if the validation fails
+	Message string
+	// Request source of the value (eg. path or header) and its name in the source
+	Source     Source
+	SourceName string
+	// Values of the enum type of the field (see Enum), used by `enum` conditions
+	Enum []any
 	// Inner Struct validation
 	InnerValidation []Validation
 }
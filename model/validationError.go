@@ -0,0 +1,112 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ValidationError is the error of a field that failed its validation.
+// If Missing is true the key of the field was not in the JsonMap and Err is nil.
+// Errors of nested structs and arrays of structs are wrapped into the ValidationError of their parent field,
+// Index is the index of the invalid item of an array of structs and nil for all other fields.
+// Message is the custom message of the field (eg. from `msg=` in the tag), it replaces the error message.
+type ValidationError struct {
+	Key     string
+	Index   *int
+	Missing bool
+	Message string
+	Err     error
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Message) > 0 {
+		return fmt.Sprintf("field %v invalid: %v", e.Key, e.Message)
+	} else if e.Missing {
+		return fmt.Sprintf("json %v key not in map", e.Key)
+	}
+	return fmt.Sprintf("field %v invalid: %v", e.Key, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ConditionError is the error of a single condition (eg. `min3`) of a requirement.
+// The error message is the message of the validator of the condition.
+type ConditionError struct {
+	ConditionType  ConditionType
+	ConditionValue string
+	Err            error
+}
+
+func (e *ConditionError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ConditionError) Unwrap() error {
+	return e.Err
+}
+
+// ConditionGroupError is the error of a group of conditions connected with OR where no condition was fulfilled.
+// Description is the natural-language description of the group (eg. `must be empty, or exactly 'Test'`).
+type ConditionGroupError struct {
+	Errors      []error
+	Description string
+}
+
+func (e *ConditionGroupError) Error() string {
+	return fmt.Sprintf("%v, all errors: %v", e.Message(), e.Errors)
+}
+
+// Message returns the description of the group or `no condition fulfilled` without the errors of the conditions.
+func (e *ConditionGroupError) Message() string {
+	if len(e.Description) > 0 {
+		return e.Description
+	}
+	return "no condition fulfilled"
+}
+
+func (e *ConditionGroupError) Unwrap() []error {
+	return e.Errors
+}
+
+// GroupError is the error of a group with too few (min) or too many (max) fields without error.
+// Errors contains the errors of all fields of the group.
+type GroupError struct {
+	Group  *Group
+	Errors []error
+}
+
+func (e *GroupError) Error() string {
+	return fmt.Sprintf("%v, all errors: %v", e.Message(), e.Errors)
+}
+
+// Message returns the error message without the errors of the fields.
+func (e *GroupError) Message() string {
+	if e.Group.ConditionType == MAX_VALUE {
+		return fmt.Sprintf("more then %v in group %s without error", e.Group.ConditionValue, e.Group.Name)
+	}
+	return fmt.Sprintf("less then %v in group %s without error", e.Group.ConditionValue, e.Group.Name)
+}
+
+func (e *GroupError) Unwrap() []error {
+	return e.Errors
+}
+
+// IsValidationError checks if the error is or wraps a ValidationError or a GroupError,
+// so the input was invalid and not the request or the validations themselves.
+// Errors of a canceled context or an exceeded deadline are never validation errors (see IsContextError).
+func IsValidationError(err error) bool {
+	if IsContextError(err) {
+		return false
+	}
+	var validationError *ValidationError
+	var groupError *GroupError
+	return errors.As(err, &validationError) || errors.As(err, &groupError)
+}
+
+// IsContextError checks if the error is or wraps context.Canceled or context.DeadlineExceeded.
+func IsContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
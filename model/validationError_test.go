@@ -0,0 +1,39 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationError(t *testing.T) {
+	inner := errors.New("value less than minimum condition 3")
+	err := &ValidationError{Key: "address", Err: &ValidationError{Key: "city", Err: inner}}
+	assert.Equal(t, "field address invalid: field city invalid: value less than minimum condition 3", err.Error(), "Expected error message to match")
+	assert.ErrorIs(t, err, inner, "Expected error to wrap inner error")
+
+	missing := &ValidationError{Key: "name", Missing: true}
+	assert.Equal(t, "json name key not in map", missing.Error(), "Expected missing key error message to match")
+
+	withMessage := &ValidationError{Key: "name", Message: "name is too short", Err: inner}
+	assert.Equal(t, "field name invalid: name is too short", withMessage.Error(), "Expected custom message to replace error message")
+	assert.ErrorIs(t, withMessage, inner, "Expected error with custom message to wrap inner error")
+}
+
+func TestGroupError(t *testing.T) {
+	fieldErr := &ValidationError{Key: "name", Missing: true}
+	minErr := &GroupError{Group: &Group{Name: "gr1", ConditionType: MIN_VALUE, ConditionValue: "1"}, Errors: []error{fieldErr}}
+	assert.Equal(t, "less then 1 in group gr1 without error, all errors: [json name key not in map]", minErr.Error(), "Expected min group error message to match")
+	assert.ErrorIs(t, minErr, fieldErr, "Expected group error to wrap field errors")
+
+	maxErr := &GroupError{Group: &Group{Name: "gr2", ConditionType: MAX_VALUE, ConditionValue: "2"}}
+	assert.Equal(t, "more then 2 in group gr2 without error, all errors: []", maxErr.Error(), "Expected max group error message to match")
+}
+
+func TestIsValidationError(t *testing.T) {
+	assert.True(t, IsValidationError(fmt.Errorf("error validating struct: %w", &ValidationError{Key: "name", Missing: true})), "Expected wrapped validation error")
+	assert.True(t, IsValidationError(&GroupError{Group: &Group{Name: "gr1", ConditionType: MIN_VALUE}}), "Expected group error")
+	assert.False(t, IsValidationError(errors.New("duplicate validation key: name")), "Expected other error not to be a validation error")
+}
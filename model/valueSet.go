@@ -0,0 +1,34 @@
+package model
+
+import (
+	"slices"
+)
+
+// ValueSet is a set of values for lookups in constant time.
+// It is used for named value lists in `frm` and `nfr` conditions (eg. `frm@countries`).
+type ValueSet map[string]struct{}
+
+// NewValueSet creates a ValueSet with the given values.
+func NewValueSet(values ...string) ValueSet {
+	valueSet := make(ValueSet, len(values))
+	for _, value := range values {
+		valueSet[value] = struct{}{}
+	}
+	return valueSet
+}
+
+// Contains returns true if the value is in the ValueSet.
+func (r ValueSet) Contains(value string) bool {
+	_, ok := r[value]
+	return ok
+}
+
+// Values returns the sorted values of the ValueSet.
+func (r ValueSet) Values() []string {
+	values := make([]string, 0, len(r))
+	for value := range r {
+		values = append(values, value)
+	}
+	slices.Sort(values)
+	return values
+}
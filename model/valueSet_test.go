@@ -0,0 +1,28 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValueSet(t *testing.T) {
+	valueSet := NewValueSet("de", "en", "fr", "en")
+
+	t.Run("Contains value", func(t *testing.T) {
+		assert.True(t, valueSet.Contains("en"), "Expected value to be in set")
+	})
+
+	t.Run("Does not contain value", func(t *testing.T) {
+		assert.False(t, valueSet.Contains("es"), "Expected value not to be in set")
+		assert.False(t, valueSet.Contains("EN"), "Expected lookup to be case sensitive")
+	})
+
+	t.Run("Sorted values", func(t *testing.T) {
+		assert.Equal(t, []string{"de", "en", "fr"}, valueSet.Values(), "Expected sorted unique values")
+	})
+
+	t.Run("Empty set", func(t *testing.T) {
+		assert.Empty(t, NewValueSet().Values(), "Expected no values")
+	})
+}
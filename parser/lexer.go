@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/siherrmann/validator/model"
@@ -11,9 +13,12 @@ type Lexer struct {
 	Input         []rune
 	char          rune            // current char under examination
 	lastTokenType model.TokenType // last TokenType for splitting condition type from value.
+	lastCondition string          // last condition type for lexing values by condition type (eg. lists for `frm`)
 	position      int             // current position in input (points to current char)
 	nextPosition  int             // current reading position in input (after current char)
 	line          int             // line number for better error reporting, etc
+	// conditionTypes are the custom condition types, longest first
+	conditionTypes []string
 }
 
 // NewLexer creates and returns a pointer to the Lexer
@@ -23,6 +28,18 @@ func NewLexer(input string) *Lexer {
 	return l
 }
 
+// longConditionTypes are the built-in condition types with more than 3 letters.
+var longConditionTypes = []string{string(model.ENUM)}
+
+// SetConditionTypes sets the names of custom condition types, which can be longer than 3 letters.
+// The longest condition type matching the input is used (eg. `currency` before `cur`).
+func (l *Lexer) SetConditionTypes(conditionTypes []string) {
+	l.conditionTypes = slices.Clone(conditionTypes)
+	slices.SortFunc(l.conditionTypes, func(a, b string) int {
+		return len(b) - len(a)
+	})
+}
+
 func (l *Lexer) readChar() {
 	if l.nextPosition >= len(l.Input) {
 		// End of input (haven't read anything yet or EOF)
@@ -32,96 +49,102 @@ func (l *Lexer) readChar() {
 		l.char = l.Input[l.nextPosition]
 	}
 
+	if l.char == '\n' && l.nextPosition > l.position {
+		l.line++
+	}
 	l.position = l.nextPosition
 	l.nextPosition++
 }
 
-// NextToken switches through the lexer's current char and creates a new model.
-// It then it calls readChar to advance the lexer and it returns the token.
+// NextToken switches through the lexer's current char and creates a new model.Token.
+// Every token has the line and the start and end position (exclusive) of its literal in the input.
+// Tokens of strings contain the unescaped string.
 func (l *Lexer) NextToken() model.Token {
-	var t model.Token
-
 	l.skipWhitespace()
 
-	switch l.char {
-	case '-':
-		t = newToken(model.LexerEmptyRequirement, l.line, l.position, l.position+1, l.char)
-	case '(':
-		t = newToken(model.LexerLeftBrace, l.line, l.position, l.position+1, l.char)
-	case ')':
-		t = newToken(model.LexerRightBrace, l.line, l.position, l.position+1, l.char)
-	case '|', '&':
-		t.Literal = l.readOperator()
-
-		t.Line = l.line
-		t.Start = l.position
-		t.End = l.position + 2
+	t := model.Token{Line: l.line, Start: l.position}
+	expectValue := l.lastTokenType == model.LexerConditionType
 
+	switch {
+	case l.char == 0:
+		t.Type = model.LexerEOF
+	case l.char == '(':
+		t.Type = model.LexerLeftBrace
+		t.Literal = string(l.char)
+		l.readChar()
+	case l.char == ')':
+		t.Type = model.LexerRightBrace
+		t.Literal = string(l.char)
+		l.readChar()
+	case isOperator(l.char):
 		t.Type = model.LexerOperator
-		l.lastTokenType = model.LexerOperator
-	case '\'':
-		t.Literal = l.readString()
-
-		t.Line = l.line
-		t.Start = l.position
-		t.End = l.position + 1
-
+		t.Literal = l.readOperator()
+	case expectValue && l.char == ':' && l.lastCondition == string(model.FUNC):
+		t.Type = model.LexerFunctionCall
+		literal, ok := l.readFunctionCall()
+		t.Literal = literal
+		if !ok {
+			t.Type = model.LexerIllegal
+		}
+	case l.char == '\'':
+		t.Type = model.LexerConditionValueString
+		literal, ok := l.readString()
+		t.Literal = literal
+		if !ok {
+			t.Type = model.LexerIllegal
+		}
+	case l.char == '"':
+		t.Type = model.LexerConditionValueString
+		literal, ok := l.readDoubleQuotedString()
+		t.Literal = literal
+		if !ok {
+			t.Type = model.LexerIllegal
+		}
+	case expectValue && l.char == '[' && model.ConditionTypeAcceptsList(model.ConditionType(l.lastCondition)):
+		t.Type = model.LexerConditionValueList
+		literal, ok := l.readBalanced('[', ']')
+		t.Literal = literal
+		if !ok {
+			t.Type = model.LexerIllegal
+		}
+	case expectValue:
 		t.Type = model.LexerConditionValue
-		l.lastTokenType = model.LexerConditionValue
-	case 0:
-		t.Literal = ""
-
-		t.Line = l.line
-
-		t.Type = model.LexerEOF
-		l.lastTokenType = model.LexerEOF
+		t.Literal = l.readConditionValue()
+	case l.char == '-':
+		t.Type = model.LexerEmptyRequirement
+		t.Literal = string(l.char)
+		l.readChar()
+	case l.char == '@':
+		t.Type = model.LexerAlias
+		t.Literal = l.readAlias()
+	case isLetter(l.char):
+		t.Type = model.LexerConditionType
+		t.Literal = l.readConditionType()
+		l.lastCondition = t.Literal
 	default:
-		if l.lastTokenType == model.LexerConditionType {
-			t.Literal = l.readConditionValue()
-
-			t.Line = l.line
-			t.Start = l.position
-			t.End = l.position
-
-			t.Type = model.LexerConditionValue
-			l.lastTokenType = model.LexerConditionValue
-			return t
-		} else if isLetter(l.char) {
-			t.Literal = l.readConditionType()
-
-			t.Line = l.line
-			t.Start = l.position
-			t.End = l.position
-
-			t.Type = model.LexerConditionType
-			l.lastTokenType = model.LexerConditionType
-			return t
-		}
-		t = newToken(model.LexerIllegal, l.line, l.position, l.position, l.char)
+		t.Type = model.LexerIllegal
+		t.Literal = string(l.char)
+		l.readChar()
 	}
 
-	l.readChar()
+	t.End = l.position
+	l.lastTokenType = t.Type
+	if t.Type == model.LexerConditionType && !model.ConditionTypeHasValue(model.ConditionType(t.Literal)) {
+		// the next token is no condition value
+		l.lastTokenType = model.LexerConditionValue
+	}
 
 	return t
 }
 
 func (l *Lexer) skipWhitespace() {
-	for l.char == ' ' || l.char == '\t' || l.char == '\n' || l.char == '\r' {
-		if l.char == '\n' {
-			l.line++
-		}
+	for isWhitespace(l.char) {
 		l.readChar()
 	}
 }
 
-func newToken(tokenType model.TokenType, line, start, end int, char ...rune) model.Token {
-	return model.Token{
-		Type:    tokenType,
-		Literal: string(char),
-		Line:    line,
-		Start:   start,
-		End:     end,
-	}
+func isWhitespace(char rune) bool {
+	return char == ' ' || char == '\t' || char == '\n' || char == '\r'
 }
 
 func isLetter(char rune) bool {
@@ -132,6 +155,11 @@ func isOperator(char rune) bool {
 	return char == '|' || char == '&'
 }
 
+// isIdentifierChar checks for the chars of identifiers like function and alias names (eg. `checkName_2`).
+func isIdentifierChar(char rune) bool {
+	return ('a' <= char && char <= 'z') || ('A' <= char && char <= 'Z') || ('0' <= char && char <= '9') || char == '_'
+}
+
 // readOperator sets a start position and reads through two characters to get a full operator
 func (l *Lexer) readOperator() string {
 	position := l.position
@@ -141,28 +169,104 @@ func (l *Lexer) readOperator() string {
 	return string(l.Input[position:l.position])
 }
 
-// readString sets a start position and reads through characters
-// until it finds a closing `'`. It stops consuming characters and
-// returns the string between the start and end positions.
-// The charakter`'` inside the string is escaped with a '/'
+// readString reads a string in `'` and returns it without the quotes.
+// The charakter `'` inside the string is escaped with a '/'
 // and the '/' is removed after reading the string.
-func (l *Lexer) readString() string {
+// It returns false if the string is not closed.
+func (l *Lexer) readString() (string, bool) {
 	position := l.position + 1
 	for {
 		prevChar := l.char
 		l.readChar()
-		if (l.char == '\'' && prevChar != '/') || l.char == 0 {
+		if l.char == 0 {
+			return string(l.Input[position:l.position]), false
+		} else if l.char == '\'' && prevChar != '/' {
 			break
 		}
 	}
+	value := string(l.Input[position:l.position])
+	// skip closing `'`
+	l.readChar()
 	// remove custom escaped `'`
-	return strings.ReplaceAll(string(l.Input[position:l.position]), "/'", "'")
+	return strings.ReplaceAll(value, "/'", "'"), true
+}
+
+// readDoubleQuotedString reads a string in `"` with the escapes of Go strings
+// (eg. `\"`, `\\`, `\n` or `ä`) and returns it unescaped without the quotes.
+// It returns false if the string is not closed or has an invalid escape.
+func (l *Lexer) readDoubleQuotedString() (string, bool) {
+	position := l.position
+	for {
+		l.readChar()
+		if l.char == '\\' {
+			l.readChar()
+			if l.char == 0 {
+				break
+			}
+			continue
+		}
+		if l.char == '"' || l.char == 0 {
+			break
+		}
+	}
+	if l.char == 0 {
+		return string(l.Input[position:l.position]), false
+	}
+	// skip closing `"`
+	l.readChar()
+
+	value, err := strconv.Unquote(string(l.Input[position:l.position]))
+	if err != nil {
+		return string(l.Input[position:l.position]), false
+	}
+	return value, true
+}
+
+// readBalanced reads from the open char to the matching close char (eg. `[` and `]`) and returns
+// the content between them. Strings in `'` or `"` and nested braces are skipped.
+// It returns false if the open char is not closed.
+func (l *Lexer) readBalanced(open rune, close rune) (string, bool) {
+	position := l.position + 1
+	depth := 0
+	var quote rune
+	for {
+		switch {
+		case l.char == 0:
+			return string(l.Input[position:l.position]), false
+		case quote == '\'' && l.char == '\'' && l.Input[l.position-1] != '/',
+			quote == '"' && l.char == '"':
+			quote = 0
+		case quote == '"' && l.char == '\\':
+			l.readChar()
+			if l.char == 0 {
+				return "", false
+			}
+		case quote == 0 && (l.char == '\'' || l.char == '"'):
+			quote = l.char
+		case quote == 0 && l.char == open:
+			depth++
+		case quote == 0 && l.char == close:
+			depth--
+		}
+		l.readChar()
+		if depth == 0 {
+			return string(l.Input[position : l.position-1]), true
+		}
+	}
 }
 
 // readConditionType sets a start position and reads through 3 characters
-// to get a condition type (unvalidated).
+// to get a condition type (unvalidated). Custom condition types are read completely.
 func (l *Lexer) readConditionType() string {
 	position := l.position
+	for _, conditionType := range slices.Concat(l.conditionTypes, longConditionTypes) {
+		if strings.HasPrefix(string(l.Input[position:]), conditionType) {
+			for l.position < position+len([]rune(conditionType)) {
+				l.readChar()
+			}
+			return conditionType
+		}
+	}
 	for isLetter(l.char) && l.position < position+3 {
 		l.readChar()
 	}
@@ -171,9 +275,47 @@ func (l *Lexer) readConditionType() string {
 
 // readConditionValue sets a start position and reads through characters
 // until any kind of whitespace to get a condition value (unvalidated).
+// Braces inside the value have to be balanced (eg. `^(a|b)$`), an unbalanced `)` ends the value.
 func (l *Lexer) readConditionValue() string {
 	position := l.position
-	for l.char != ' ' && l.char != '\t' && l.char != '\n' && l.char != '\r' && l.char != ')' && l.char != 0 {
+	depth := 0
+	for !isWhitespace(l.char) && l.char != 0 && (l.char != ')' || depth > 0) {
+		if l.char == '(' {
+			depth++
+		} else if l.char == ')' {
+			depth--
+		}
+		l.readChar()
+	}
+	return string(l.Input[position:l.position])
+}
+
+// readFunctionCall reads a function call like `:near(52.5, 13.4, 'km')` after a condition type.
+// It returns the call without the leading `:` (eg. `near(52.5, 13.4, 'km')`).
+// The argument list may contain whitespace, strings in `'` or `"` and nested braces.
+// It returns false if the argument list is not closed.
+func (l *Lexer) readFunctionCall() (string, bool) {
+	// skip `:`
+	l.readChar()
+	position := l.position
+	for isIdentifierChar(l.char) {
+		l.readChar()
+	}
+	if l.char != '(' {
+		return string(l.Input[position:l.position]), true
+	}
+
+	_, ok := l.readBalanced('(', ')')
+	return string(l.Input[position:l.position]), ok
+}
+
+// readAlias reads an alias like `@password` and returns its name without the leading `@`.
+// Alias names consist of letters, digits and `_`.
+func (l *Lexer) readAlias() string {
+	// skip `@`
+	l.readChar()
+	position := l.position
+	for isIdentifierChar(l.char) {
 		l.readChar()
 	}
 	return string(l.Input[position:l.position])
@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/siherrmann/validator/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexer(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []model.Token
+	}{
+		{
+			name:  "Conditions with positions",
+			input: "min1 || equ'a b'",
+			expected: []model.Token{
+				{Type: model.LexerConditionType, Literal: "min", Start: 0, End: 3},
+				{Type: model.LexerConditionValue, Literal: "1", Start: 3, End: 4},
+				{Type: model.LexerOperator, Literal: "||", Start: 5, End: 7},
+				{Type: model.LexerConditionType, Literal: "equ", Start: 8, End: 11},
+				{Type: model.LexerConditionValueString, Literal: "a b", Start: 11, End: 16},
+				{Type: model.LexerEOF, Start: 16, End: 16},
+			},
+		},
+		{
+			name:  "Double quoted string with unicode and lines",
+			input: "(equ\"ä\\n\"\n&& enum)",
+			expected: []model.Token{
+				{Type: model.LexerLeftBrace, Literal: "(", Start: 0, End: 1},
+				{Type: model.LexerConditionType, Literal: "equ", Start: 1, End: 4},
+				{Type: model.LexerConditionValueString, Literal: "ä\n", Start: 4, End: 9},
+				{Type: model.LexerOperator, Literal: "&&", Line: 1, Start: 10, End: 12},
+				{Type: model.LexerConditionType, Literal: "enum", Line: 1, Start: 13, End: 17},
+				{Type: model.LexerRightBrace, Literal: ")", Line: 1, Start: 17, End: 18},
+				{Type: model.LexerEOF, Line: 1, Start: 18, End: 18},
+			},
+		},
+		{
+			name:  "List literal and function call",
+			input: "frm['a]', 2] fun:near(1, ')')",
+			expected: []model.Token{
+				{Type: model.LexerConditionType, Literal: "frm", Start: 0, End: 3},
+				{Type: model.LexerConditionValueList, Literal: "'a]', 2", Start: 3, End: 12},
+				{Type: model.LexerConditionType, Literal: "fun", Start: 13, End: 16},
+				{Type: model.LexerFunctionCall, Literal: "near(1, ')')", Start: 16, End: 29},
+				{Type: model.LexerEOF, Start: 29, End: 29},
+			},
+		},
+		{
+			name:  "Negative number and regex with braces",
+			input: "min-1 rex^(a|b)$)",
+			expected: []model.Token{
+				{Type: model.LexerConditionType, Literal: "min", Start: 0, End: 3},
+				{Type: model.LexerConditionValue, Literal: "-1", Start: 3, End: 5},
+				{Type: model.LexerConditionType, Literal: "rex", Start: 6, End: 9},
+				{Type: model.LexerConditionValue, Literal: "^(a|b)$", Start: 9, End: 16},
+				{Type: model.LexerRightBrace, Literal: ")", Start: 16, End: 17},
+				{Type: model.LexerEOF, Start: 17, End: 17},
+			},
+		},
+		{
+			name:  "Invalid unclosed list literal",
+			input: "nfr['a'",
+			expected: []model.Token{
+				{Type: model.LexerConditionType, Literal: "nfr", Start: 0, End: 3},
+				{Type: model.LexerIllegal, Literal: "'a'", Start: 3, End: 7},
+				{Type: model.LexerEOF, Start: 7, End: 7},
+			},
+		},
+		{
+			name:  "Invalid list literal ending with an escape",
+			input: `frm["\`,
+			expected: []model.Token{
+				{Type: model.LexerConditionType, Literal: "frm", Start: 0, End: 3},
+				{Type: model.LexerIllegal, Start: 3, End: 6},
+				{Type: model.LexerEOF, Start: 6, End: 6},
+			},
+		},
+		{
+			name:  "Invalid function call ending with an escape",
+			input: `fun:a("\`,
+			expected: []model.Token{
+				{Type: model.LexerConditionType, Literal: "fun", Start: 0, End: 3},
+				{Type: model.LexerIllegal, Literal: `a("\`, Start: 3, End: 8},
+				{Type: model.LexerEOF, Start: 8, End: 8},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lexer := NewLexer(test.input)
+			tokens := []model.Token{}
+			for {
+				token := lexer.NextToken()
+				tokens = append(tokens, token)
+				if token.Type == model.LexerEOF {
+					break
+				}
+			}
+			assert.Equal(t, test.expected, tokens, "Expected tokens to match")
+		})
+	}
+}
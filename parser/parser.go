@@ -1,10 +1,12 @@
 package parser
 
 import (
-	"errors"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/siherrmann/validator/helper"
 	"github.com/siherrmann/validator/model"
 )
 
@@ -12,9 +14,21 @@ import (
 // Parser methods handle iterating through tokens and building and AST.
 type Parser struct {
 	lexer        *Lexer
-	errors       []string
+	errors       []*model.ParseError
 	currentToken model.Token
 	peekToken    model.Token
+	// funcArities are the number of arguments of the known functions by name.
+	funcArities map[string]int
+	// conditionTypes are the value parsers of the custom condition types by name.
+	conditionTypes map[model.ConditionType]model.ConditionValueParser
+	// aliases are the requirements of the aliases by name.
+	aliases map[string]string
+	// valueLists are the named value lists by name.
+	valueLists map[string]model.ValueSet
+	// enumValues are the values for `enum` conditions.
+	enumValues model.ValueSet
+	// aliasStack are the names of the aliases currently expanded, used for cycle detection.
+	aliasStack []string
 }
 
 // NewParser creates a new Parser instance.
@@ -22,13 +36,59 @@ func NewParser() *Parser {
 	return &Parser{}
 }
 
+// SetFuncArities sets the number of arguments of the known functions by name.
+// Function calls like `fun:divisible(5)` of known functions are checked for their number
+// of arguments while parsing, a negative arity allows any number of arguments.
+// Calls of unknown functions are not checked.
+func (p *Parser) SetFuncArities(funcArities map[string]int) *Parser {
+	p.funcArities = funcArities
+	return p
+}
+
+// SetConditionTypes sets the custom condition types with their value parsers (a parser can be nil).
+// Custom condition types are used like the built-in condition types (eg. `currencyEUR,USD`).
+func (p *Parser) SetConditionTypes(conditionTypes map[model.ConditionType]model.ConditionValueParser) *Parser {
+	p.conditionTypes = conditionTypes
+	return p
+}
+
+// SetAliases sets the requirements of named aliases, which are referenced with `@name` (eg. `@password`).
+// Aliases are expanded as a group while parsing and can reference other aliases, cycles return an error.
+func (p *Parser) SetAliases(aliases map[string]string) *Parser {
+	p.aliases = aliases
+	return p
+}
+
+// SetValueLists sets the named value lists, which are referenced in `frm` and `nfr` conditions
+// with `@name` (eg. `frm@plans`). They replace the built-in value lists with the same name
+// (see helper.BuiltInValueList). Unknown value lists return an error while parsing.
+func (p *Parser) SetValueLists(valueLists map[string]model.ValueSet) *Parser {
+	p.valueLists = valueLists
+	return p
+}
+
+// SetEnumValues sets the values for `enum` conditions, which are set as ParsedValue of the conditions.
+// Without values the enum values are taken from the type of the validated value (see model.Enum).
+func (p *Parser) SetEnumValues(enumValues model.ValueSet) *Parser {
+	p.enumValues = enumValues
+	return p
+}
+
 // ParseValidation parses tokens and creates an AST. It returns the RootNode
 // which holds a Value and in it the rest of the tree.
 // It creates a new Lexer for reset lexer state and initializes the parser's tokens.
-// It returns an error if the parsing fails.
+// The parser continues after an error, so all errors of the requirement are returned
+// together as model.ParseErrors with the position of each error.
 func (p *Parser) ParseValidation(validation string) (model.RootNode, error) {
-	p.errors = []string{}
+	p.errors = []*model.ParseError{}
 	p.lexer = NewLexer(validation)
+	if len(p.conditionTypes) > 0 {
+		conditionTypes := []string{}
+		for conditionType := range p.conditionTypes {
+			conditionTypes = append(conditionTypes, string(conditionType))
+		}
+		p.lexer.SetConditionTypes(conditionTypes)
+	}
 
 	// Read two tokens, so currentToken and peekToken are both set.
 	p.nextToken()
@@ -37,12 +97,8 @@ func (p *Parser) ParseValidation(validation string) (model.RootNode, error) {
 	var rootNode model.RootNode
 
 	val := p.parseGroup(true)
-	if val == nil || len(p.Errors()) > 0 {
-		p.parseError(fmt.Sprintf(
-			"error parsing validation, expected a value, got: %v:",
-			p.currentToken.Literal,
-		))
-		return model.RootNode{RootValue: &model.AstValue{}}, errors.New(p.Errors())
+	if len(p.errors) > 0 {
+		return model.RootNode{RootValue: &model.AstValue{}}, model.ParseErrors(p.errors)
 	}
 	rootNode.RootValue = val
 
@@ -61,15 +117,12 @@ func (p *Parser) currentTokenTypeIs(t model.TokenType) bool {
 }
 
 // parseGroup is called when an open left brace `(` token is found or a requirement starts without a '('.
+// Unexpected tokens are added as error and skipped, so the parsing continues after the error.
 func (p *Parser) parseGroup(root bool) *model.AstValue {
 	group := &model.AstValue{Type: model.GROUP}
 	grpState := model.GrpStart
 
 	for !p.currentTokenTypeIs(model.LexerEOF) && grpState != model.GrpEnd {
-		if len(p.errors) > 0 {
-			return nil
-		}
-
 		switch grpState {
 		case model.GrpStart:
 			if p.currentTokenTypeIs(model.LexerLeftBrace) {
@@ -82,7 +135,7 @@ func (p *Parser) parseGroup(root bool) *model.AstValue {
 					p.nextToken()
 					grpState = model.GrpOpen
 				}
-			} else if p.currentTokenTypeIs(model.LexerConditionType) {
+			} else if p.currentTokenTypeIs(model.LexerConditionType) || p.currentTokenTypeIs(model.LexerAlias) {
 				group.Start = p.currentToken.Start
 				grpState = model.GrpOpen
 			} else if p.currentTokenTypeIs(model.LexerEmptyRequirement) {
@@ -92,14 +145,17 @@ func (p *Parser) parseGroup(root bool) *model.AstValue {
 				grpState = model.GrpEnd
 				return group
 			} else {
-				p.parseError(fmt.Sprintf(
-					"error parsing validation group, expected left brace, `-` or condition, got: %s",
+				p.expectError("left brace, `-`, condition or alias", fmt.Sprintf(
+					"error parsing validation group, expected left brace, `-`, condition or alias, got: %s",
 					p.currentToken.Literal,
 				))
-				return nil
+				p.nextToken()
 			}
 		case model.GrpOpen:
-			if p.currentTokenTypeIs(model.LexerRightBrace) {
+			if p.currentTokenTypeIs(model.LexerRightBrace) && root {
+				p.expectError("condition, alias or operator", "error parsing group, unexpected right brace without left brace")
+				p.nextToken()
+			} else if p.currentTokenTypeIs(model.LexerRightBrace) {
 				group.End = p.currentToken.End
 				p.nextToken()
 				grpState = model.GrpEnd
@@ -115,27 +171,36 @@ func (p *Parser) parseGroup(root bool) *model.AstValue {
 				if len(group.ConditionGroup) > 1 && len(group.ConditionGroup[len(group.ConditionGroup)-2].Operator) == 0 {
 					group.ConditionGroup[len(group.ConditionGroup)-2].Operator = model.AND
 				}
+			} else if p.currentTokenTypeIs(model.LexerAlias) {
+				aliasGroup := p.parseAlias()
+				if aliasGroup == nil {
+					continue
+				}
+				group.ConditionGroup = append(group.ConditionGroup, aliasGroup)
+				if len(group.ConditionGroup) > 1 && len(group.ConditionGroup[len(group.ConditionGroup)-2].Operator) == 0 {
+					group.ConditionGroup[len(group.ConditionGroup)-2].Operator = model.AND
+				}
 			} else if p.currentTokenTypeIs(model.LexerOperator) && len(group.ConditionGroup) > 0 {
 				operator := p.parseOperator()
 				group.ConditionGroup[len(group.ConditionGroup)-1].Operator = operator
 				p.nextToken()
 			} else {
-				p.parseError(fmt.Sprintf(
-					"error parsing group, expected right brace, condition or operator, got: %s, type: %v",
+				p.expectError("right brace, condition, alias or operator", fmt.Sprintf(
+					"error parsing group, expected right brace, condition, alias or operator, got: %s, type: %v",
 					p.currentToken.Literal,
 					p.currentToken.Type,
 				))
-				return nil
+				p.nextToken()
 			}
 		}
 	}
 
 	if p.currentTokenTypeIs(model.LexerEOF) && grpState == model.GrpOpen && !root {
-		p.parseError(fmt.Sprintf(
+		p.expectError("right brace", fmt.Sprintf(
 			"error parsing group, expected right brace, got end of line after: %s",
 			p.lexer.lastTokenType,
 		))
-		return nil
+		return group
 	}
 
 	group.End = p.currentToken.Start
@@ -144,8 +209,9 @@ func (p *Parser) parseGroup(root bool) *model.AstValue {
 }
 
 // parseCondition is used to parse a condition and setting the `conditionType`:`condition` pair.
+// An invalid condition value is added as error and skipped.
 func (p *Parser) parseCondition() *model.AstValue {
-	condition := &model.AstValue{Type: model.CONDITION}
+	condition := &model.AstValue{Type: model.CONDITION, Start: p.currentToken.Start}
 	conditionState := model.ConType
 
 	for conditionState != model.ConEnd {
@@ -153,10 +219,17 @@ func (p *Parser) parseCondition() *model.AstValue {
 		case model.ConType:
 			if p.currentTokenTypeIs(model.LexerConditionType) {
 				condition.ConditionType = p.parseConditionType()
+				condition.End = p.currentToken.End
 				p.nextToken()
 				conditionState = model.ConValue
+				if !model.ConditionTypeHasValue(condition.ConditionType) {
+					if p.enumValues != nil {
+						condition.ParsedValue = p.enumValues
+					}
+					conditionState = model.ConEnd
+				}
 			} else {
-				p.parseError(fmt.Sprintf(
+				p.expectError("condition type", fmt.Sprintf(
 					"error parsing condition type, expected CndType token, got: %s",
 					p.currentToken.Literal,
 				))
@@ -165,13 +238,41 @@ func (p *Parser) parseCondition() *model.AstValue {
 		case model.ConValue:
 			if p.currentTokenTypeIs(model.LexerConditionValue) || p.currentTokenTypeIs(model.LexerConditionValueString) {
 				condition.ConditionValue = p.parseConditionValue()
+				condition.ParsedValue = p.parseCustomConditionValue(condition.ConditionType, condition.ConditionValue)
+				if (condition.ConditionType == model.FROM || condition.ConditionType == model.NOT_FROM) && strings.HasPrefix(condition.ConditionValue, "@") {
+					condition.ParsedValue = p.parseValueList(condition.ConditionValue)
+				}
+				p.checkNumericConditionValue(condition.ConditionType, condition.ConditionValue)
+				condition.End = p.currentToken.End
 				p.nextToken()
 				conditionState = model.ConEnd
+			} else if p.currentTokenTypeIs(model.LexerConditionValueList) && model.ConditionTypeAcceptsList(condition.ConditionType) {
+				condition.ConditionValue = strings.TrimSpace(p.currentToken.Literal)
+				condition.ConditionArgs, condition.ParsedValue = p.parseListValue()
+				condition.End = p.currentToken.End
+				p.nextToken()
+				conditionState = model.ConEnd
+			} else if p.currentTokenTypeIs(model.LexerFunctionCall) && condition.ConditionType == model.FUNC {
+				condition.ConditionValue, condition.ConditionArgs = p.parseFunctionCall()
+				condition.End = p.currentToken.End
+				p.nextToken()
+				conditionState = model.ConEnd
+			} else if p.currentTokenTypeIs(model.LexerIllegal) {
+				p.expectError("condition value", fmt.Sprintf(
+					"error parsing condition, invalid or unclosed value: %s",
+					p.currentToken.Literal,
+				))
+				p.nextToken()
+				return condition
 			} else {
-				p.parseError(fmt.Sprintf(
+				p.expectError("condition value", fmt.Sprintf(
 					"error parsing condition, expected ConValue token, got: %s",
 					p.currentToken.Literal,
 				))
+				// skip values not allowed for the condition type (eg. a function call of another condition type)
+				if p.currentTokenTypeIs(model.LexerFunctionCall) || p.currentTokenTypeIs(model.LexerConditionValueList) {
+					p.nextToken()
+				}
 				return condition
 			}
 		}
@@ -180,6 +281,53 @@ func (p *Parser) parseCondition() *model.AstValue {
 	return condition
 }
 
+// parseAlias is used to expand an alias (eg. `@password`) into a group with the parsed requirement of the alias.
+// The requirement is parsed by a new parser with the same settings, so aliases can reference other aliases.
+// It returns nil if the alias can not be expanded.
+func (p *Parser) parseAlias() *model.AstValue {
+	aliasToken := p.currentToken
+	p.nextToken()
+
+	name := aliasToken.Literal
+	requirement, ok := p.aliases[name]
+	if !ok {
+		p.parseErrorAt(aliasToken, "", fmt.Sprintf("error parsing alias, unknown alias: @%s", name))
+		return nil
+	}
+	if slices.Contains(p.aliasStack, name) {
+		p.parseErrorAt(aliasToken, "", fmt.Sprintf(
+			"error parsing alias, cycle in aliases: @%s -> @%s",
+			strings.Join(p.aliasStack, " -> @"),
+			name,
+		))
+		return nil
+	}
+
+	aliasParser := &Parser{
+		funcArities:    p.funcArities,
+		conditionTypes: p.conditionTypes,
+		aliases:        p.aliases,
+		valueLists:     p.valueLists,
+		enumValues:     p.enumValues,
+		aliasStack:     append(slices.Clone(p.aliasStack), name),
+	}
+	aliasNode, err := aliasParser.ParseValidation(requirement)
+	if err != nil {
+		p.parseErrorAt(aliasToken, "", fmt.Sprintf("error parsing alias @%s with error: %v", name, err.Error()))
+		return nil
+	}
+	if aliasNode.RootValue.Type != model.GROUP || len(aliasNode.RootValue.ConditionGroup) == 0 {
+		p.parseErrorAt(aliasToken, "", fmt.Sprintf("error parsing alias @%s, expected at least one condition", name))
+		return nil
+	}
+
+	aliasGroup := aliasNode.RootValue
+	aliasGroup.Start = aliasToken.Start
+	aliasGroup.End = aliasToken.End
+
+	return aliasGroup
+}
+
 // parseOperator is used to parse the condition type.
 func (p *Parser) parseOperator() model.Operator {
 	operator := model.Operator(p.currentToken.Literal)
@@ -197,6 +345,9 @@ func (p *Parser) parseOperator() model.Operator {
 // parseConditionType is used to parse the condition type.
 func (p *Parser) parseConditionType() model.ConditionType {
 	conType := model.ConditionType(p.currentToken.Literal)
+	if _, ok := p.conditionTypes[conType]; ok {
+		return conType
+	}
 	err := model.LookupConditionType(conType)
 	if err != nil {
 		p.parseError(fmt.Sprintf(
@@ -213,13 +364,210 @@ func (p *Parser) parseConditionValue() string {
 	return p.currentToken.Literal
 }
 
-// parseError is very similar to `peekError`, except it simply takes a string message that
-// gets appended to the parser's errors
+// parseCustomConditionValue is used to parse the condition value with the value parser of a custom condition type.
+// It returns nil for built-in condition types and custom condition types without value parser.
+func (p *Parser) parseCustomConditionValue(conType model.ConditionType, value string) any {
+	valueParser := p.conditionTypes[conType]
+	if valueParser == nil {
+		return nil
+	}
+	parsedValue, err := valueParser(value)
+	if err != nil {
+		p.parseError(fmt.Sprintf(
+			"error parsing value %s of condition type %s with error: %v",
+			value,
+			conType,
+			err.Error(),
+		))
+	}
+	return parsedValue
+}
+
+// parseValueList is used to look up a named value list (eg. `@countries` for `frm@countries`).
+// The built-in value lists are only looked up if no value list with the name is set,
+// so they are not created before they are referenced.
+func (p *Parser) parseValueList(value string) model.ValueSet {
+	name := strings.TrimPrefix(value, "@")
+	valueList, ok := p.valueLists[name]
+	if !ok {
+		valueList, ok = helper.BuiltInValueList(name)
+	}
+	if !ok {
+		p.parseError(fmt.Sprintf("error parsing value list, unknown value list: @%s", name))
+		return nil
+	}
+	return valueList
+}
+
+// checkNumericConditionValue checks the value of numeric condition types at parse time,
+// `min` and `max` need a number (eg. `min-1.5`) and `fmn` and `fmx` a byte size (eg. `fmx2MB`).
+func (p *Parser) checkNumericConditionValue(conType model.ConditionType, value string) {
+	var err error
+	switch conType {
+	case model.MIN_VALUE, model.MAX_VALUE:
+		_, err = strconv.ParseFloat(value, 64)
+	case model.FILE_MIN_SIZE, model.FILE_MAX_SIZE:
+		_, err = helper.ByteSizeToInt(value)
+	}
+	if err != nil {
+		p.parseError(fmt.Sprintf("error parsing value %s of condition type %s, expected a number", value, conType))
+	}
+}
+
+// parseListValue is used to parse a list literal (eg. `'a', 'b c'` for frm['a', 'b c'])
+// into its typed items and a ValueSet of the items.
+func (p *Parser) parseListValue() ([]any, model.ValueSet) {
+	items, err := parseLiterals(p.currentToken.Literal)
+	if err != nil {
+		p.parseError(fmt.Sprintf("error parsing list [%s]: %v", p.currentToken.Literal, err))
+		return nil, nil
+	}
+	if len(items) == 0 {
+		p.parseError("error parsing list [], expected at least one item")
+		return nil, nil
+	}
+	valueSet, err := helper.EnumValueSet(items)
+	if err != nil {
+		p.parseError(fmt.Sprintf("error parsing list [%s]: %v", p.currentToken.Literal, err))
+		return nil, nil
+	}
+	return items, valueSet
+}
+
+// parseFunctionCall is used to parse a function call (eg. divisible(5) for fun:divisible(5))
+// into the function name and its typed arguments. The arguments are nil if the call has no argument list.
+// It checks the number of arguments if the arity of the function is known.
+func (p *Parser) parseFunctionCall() (string, []any) {
+	literal := p.currentToken.Literal
+	name, argList, hasArgs := strings.Cut(literal, "(")
+	if len(name) == 0 {
+		p.parseError(fmt.Sprintf("error parsing function call %s, expected a function name", literal))
+		return name, nil
+	} else if !model.IsIdentifier(name) {
+		p.parseError(fmt.Sprintf("error parsing function call %s, invalid function name: %s", literal, name))
+		return name, nil
+	}
+
+	var args []any
+	if hasArgs {
+		var err error
+		args, err = parseLiterals(strings.TrimSuffix(argList, ")"))
+		if err != nil {
+			p.parseError(fmt.Sprintf("error parsing arguments of function %s: %v", name, err))
+			return name, nil
+		}
+	}
+
+	if arity, ok := p.funcArities[name]; ok && arity >= 0 && arity != len(args) {
+		p.parseError(fmt.Sprintf(
+			"error parsing function call %s, expected %d arguments, got: %d",
+			name,
+			arity,
+			len(args),
+		))
+	}
+
+	return name, args
+}
+
+// parseLiterals splits a list of literals (eg. the arguments of a function call) by `,` and converts them.
+// Literals in `'` are strings (with `/'` as escaped `'`), literals in `"` are strings with the escapes
+// of Go strings (eg. `\"` or `\u00e4`), `true` and `false` are booleans,
+// numbers are int or float64 and all other literals are used as unquoted strings.
+func parseLiterals(list string) ([]any, error) {
+	literals := []any{}
+	if len(strings.TrimSpace(list)) == 0 {
+		return literals, nil
+	}
+
+	literalStrings := []string{}
+	var quote rune
+	escaped := false
+	depth := 0
+	start := 0
+	for i, char := range list {
+		switch {
+		case escaped:
+			escaped = false
+		case quote == '"' && char == '\\':
+			escaped = true
+		case quote == 0 && (char == '\'' || char == '"'):
+			quote = char
+		case quote == '\'' && char == '\'' && (i == 0 || list[i-1] != '/'),
+			quote == '"' && char == '"':
+			quote = 0
+		case quote == 0 && (char == '(' || char == '['):
+			depth++
+		case quote == 0 && (char == ')' || char == ']'):
+			depth--
+		case quote == 0 && char == ',' && depth == 0:
+			literalStrings = append(literalStrings, list[start:i])
+			start = i + 1
+		}
+	}
+	literalStrings = append(literalStrings, list[start:])
+
+	for _, literalString := range literalStrings {
+		literalString = strings.TrimSpace(literalString)
+		switch {
+		case len(literalString) == 0:
+			return nil, fmt.Errorf("empty literal")
+		case strings.HasPrefix(literalString, "'"):
+			if len(literalString) < 2 || !strings.HasSuffix(literalString, "'") {
+				return nil, fmt.Errorf("unterminated string literal: %s", literalString)
+			}
+			literals = append(literals, strings.ReplaceAll(literalString[1:len(literalString)-1], "/'", "'"))
+		case strings.HasPrefix(literalString, "\""):
+			value, err := strconv.Unquote(literalString)
+			if err != nil {
+				return nil, fmt.Errorf("invalid string literal: %s", literalString)
+			}
+			literals = append(literals, value)
+		case literalString == "true" || literalString == "false":
+			literals = append(literals, literalString == "true")
+		default:
+			if intLiteral, err := strconv.Atoi(literalString); err == nil {
+				literals = append(literals, intLiteral)
+			} else if floatLiteral, err := strconv.ParseFloat(literalString, 64); err == nil {
+				literals = append(literals, floatLiteral)
+			} else {
+				literals = append(literals, literalString)
+			}
+		}
+	}
+
+	return literals, nil
+}
+
+// parseError adds an error at the position of the current token to the parser's errors.
 func (p *Parser) parseError(msg string) {
-	p.errors = append(p.errors, msg)
+	p.parseErrorAt(p.currentToken, "", msg)
+}
+
+// expectError adds an error at the position of the current token with the description
+// of the expected token (eg. `condition value`) to the parser's errors.
+func (p *Parser) expectError(expected string, msg string) {
+	p.parseErrorAt(p.currentToken, expected, msg)
+}
+
+// parseErrorAt adds an error at the position of the token to the parser's errors.
+func (p *Parser) parseErrorAt(token model.Token, expected string, msg string) {
+	parseError := model.NewParseError(string(p.lexer.Input), token.Start, token.End, msg)
+	parseError.Expected = expected
+	// the token as written in the requirement (eg. with quotes)
+	parseError.Found = string(p.lexer.Input[parseError.Start:parseError.End])
+	if token.Type == model.LexerEOF {
+		parseError.Found = "end of requirement"
+	}
+	p.errors = append(p.errors, parseError)
 }
 
 // Errors is simply a helper function that returns the parser's errors
 func (p *Parser) Errors() string {
-	return strings.Join(p.errors, ", ")
+	return model.ParseErrors(p.errors).Error()
+}
+
+// ParseErrors returns the parser's errors with their positions.
+func (p *Parser) ParseErrors() model.ParseErrors {
+	return p.errors
 }
@@ -1,9 +1,14 @@
 package parser
 
 import (
+	"math/rand"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/siherrmann/validator/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParser(t *testing.T) {
@@ -135,6 +140,168 @@ func TestParser(t *testing.T) {
 			expected: "(min'1' && (max'2' || min'3')) || (min'4' && max'5')",
 			wantErr:  false,
 		},
+		{
+			name:     "Enum condition",
+			input:    "enum",
+			expected: "enum",
+			wantErr:  false,
+		},
+		{
+			name:     "Enum condition with other conditions",
+			input:    "(enum min1) || equ''",
+			expected: "(enum && min'1') || equ''",
+			wantErr:  false,
+		},
+		{
+			name:     "Invalid enum condition with value",
+			input:    "enum'active'",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Double quoted string with escapes",
+			input:    `equ"a \"b\" \u00e4\t" || equ"x"`,
+			expected: "equ'a \"b\" ä\t' || equ'x'",
+			wantErr:  false,
+		},
+		{
+			name:     "Invalid double quoted string with invalid escape",
+			input:    `equ"\q"`,
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Invalid unclosed double quoted string",
+			input:    `equ"abc`,
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "List literal",
+			input:    `frm['a', 'b c', "d, e", 1]`,
+			expected: "frm['a', 'b c', 'd, e', 1]",
+			wantErr:  false,
+		},
+		{
+			name:     "List literal in group",
+			input:    "(nfr['x'] || min1)",
+			expected: "(nfr['x'] || min'1')",
+			wantErr:  false,
+		},
+		{
+			name:     "Invalid empty list literal",
+			input:    "frm[]",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Invalid unclosed list literal",
+			input:    "frm['a', 'b'",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Regex starting with bracket",
+			input:    "rex[a-z]+",
+			expected: "rex'[a-z]+'",
+			wantErr:  false,
+		},
+		{
+			name:     "Regex with braces in group",
+			input:    "(min1 && rex^(a|b)+$)",
+			expected: "(min'1' && rex'^(a|b)+$')",
+			wantErr:  false,
+		},
+		{
+			name:     "Negative and decimal numbers",
+			input:    "min-1.5 && max1e3 && fmx2MB",
+			expected: "min'-1.5' && max'1e3' && fmx'2MB'",
+			wantErr:  false,
+		},
+		{
+			name:     "Invalid number",
+			input:    "minabc",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Invalid byte size",
+			input:    "fmx2XB",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Function call without arguments",
+			input:    "fun:check",
+			expected: "fun'check'",
+			wantErr:  false,
+		},
+		{
+			name:     "Function call with arguments",
+			input:    "fun:near(52.5, 13.4, 10) && min1",
+			expected: "fun:near(52.5, 13.4, 10) && min'1'",
+			wantErr:  false,
+		},
+		{
+			name:     "Function call with string and boolean arguments in group",
+			input:    "(fun:prefix('a, (b/')', true, abc) || min1)",
+			expected: "(fun:prefix('a, (b/')', true, 'abc') || min'1')",
+			wantErr:  false,
+		},
+		{
+			name:     "Function call with empty argument list",
+			input:    "fun:check()",
+			expected: "fun:check()",
+			wantErr:  false,
+		},
+		{
+			name:     "Invalid function call without closing brace",
+			input:    "fun:near(52.5, 13.4",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Invalid function call with empty argument",
+			input:    "fun:near(52.5, , 10)",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Invalid function call with unterminated string",
+			input:    "fun:prefix('a)",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Invalid function call without name",
+			input:    "fun:(1)",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Function call with camel case name and double quoted argument",
+			input:    `fun:hasPrefix_2("a\"b")`,
+			expected: "fun:hasPrefix_2('a\"b')",
+			wantErr:  false,
+		},
+		{
+			name:     "Invalid function call with invalid name",
+			input:    "fun:2check",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Values starting with colon on other condition types",
+			input:    "equ:abc || con:",
+			expected: "equ':abc' || con':'",
+			wantErr:  false,
+		},
+		{
+			name:     "Invalid function call on other condition type",
+			input:    "min:near(1)",
+			expected: "",
+			wantErr:  true,
+		},
 	}
 
 	for _, test := range tests {
@@ -151,3 +318,507 @@ func TestParser(t *testing.T) {
 		})
 	}
 }
+
+func TestParserFunctionCall(t *testing.T) {
+	parser := NewParser().SetFuncArities(map[string]int{"divisible": 1, "near": 3, "any": -1})
+
+	tests := []struct {
+		name         string
+		input        string
+		expectedName string
+		expectedArgs []any
+		wantErr      bool
+	}{
+		{
+			name:         "Valid typed arguments",
+			input:        "fun:near(52.5, 13, 'km')",
+			expectedName: "near",
+			expectedArgs: []any{52.5, 13, "km"},
+			wantErr:      false,
+		},
+		{
+			name:         "Valid single argument",
+			input:        "fun:divisible(5)",
+			expectedName: "divisible",
+			expectedArgs: []any{5},
+			wantErr:      false,
+		},
+		{
+			name:         "Valid variadic arguments",
+			input:        "fun:any(true, false, -1.5e2)",
+			expectedName: "any",
+			expectedArgs: []any{true, false, -150.0},
+			wantErr:      false,
+		},
+		{
+			name:         "Valid unknown function",
+			input:        "fun:unknown(1, 2)",
+			expectedName: "unknown",
+			expectedArgs: []any{1, 2},
+			wantErr:      false,
+		},
+		{
+			name:    "Invalid too many arguments",
+			input:   "fun:divisible(5, 6)",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid missing arguments",
+			input:   "fun:near",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rootNode, err := parser.ParseValidation(test.input)
+			if test.wantErr {
+				assert.Error(t, err, "Expected an error for input: %s", test.input)
+			} else {
+				assert.NoError(t, err, "Did not expect an error for input: %s", test.input)
+				condition := rootNode.RootValue.ConditionGroup[0]
+				assert.Equal(t, test.expectedName, condition.ConditionValue, "Expected function name to match")
+				assert.Equal(t, test.expectedArgs, condition.ConditionArgs, "Expected function arguments to match")
+			}
+		})
+	}
+}
+
+func TestParserCustomConditionTypes(t *testing.T) {
+	parser := NewParser().SetConditionTypes(map[model.ConditionType]model.ConditionValueParser{
+		"currency": func(value string) (any, error) {
+			return strings.Split(value, ","), nil
+		},
+		"minimum": nil,
+		"sku": func(value string) (any, error) {
+			return strconv.Atoi(value)
+		},
+	})
+
+	tests := []struct {
+		name           string
+		input          string
+		expected       string
+		expectedParsed []any
+		wantErr        bool
+	}{
+		{
+			name:           "Valid custom condition type",
+			input:          "currencyEUR,USD",
+			expected:       "currency'EUR,USD'",
+			expectedParsed: []any{[]string{"EUR", "USD"}},
+			wantErr:        false,
+		},
+		{
+			name:           "Valid custom condition type with string value",
+			input:          "currency'EUR' || sku8",
+			expected:       "currency'EUR' || sku'8'",
+			expectedParsed: []any{[]string{"EUR"}, 8},
+			wantErr:        false,
+		},
+		{
+			name:           "Valid longest condition type",
+			input:          "minimum5 && min3",
+			expected:       "minimum'5' && min'3'",
+			expectedParsed: []any{nil, nil},
+			wantErr:        false,
+		},
+		{
+			name:    "Invalid custom condition value",
+			input:   "skuABC",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid custom condition type without value",
+			input:   "(currency)",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rootNode, err := parser.ParseValidation(test.input)
+			if test.wantErr {
+				assert.Error(t, err, "Expected an error for input: %s", test.input)
+			} else {
+				assert.NoError(t, err, "Did not expect an error for input: %s", test.input)
+				assert.Equal(t, test.expected, rootNode.RootValue.AstGroupToString(), "Expected requirement to match")
+				parsedValues := []any{}
+				for _, condition := range rootNode.RootValue.ConditionGroup {
+					parsedValues = append(parsedValues, condition.ParsedValue)
+				}
+				assert.Equal(t, test.expectedParsed, parsedValues, "Expected parsed values to match")
+			}
+		})
+	}
+}
+
+func TestParserAliases(t *testing.T) {
+	parser := NewParser().SetAliases(map[string]string{
+		"password": "min8 max30 @upper",
+		"upper":    "rex'[A-Z]'",
+		"name":     "min1 || equ''",
+		"cycle1":   "min1 @cycle2",
+		"cycle2":   "@cycle1",
+		"self":     "@self",
+		"invalid":  "(min1",
+	})
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "Valid alias",
+			input:    "@name",
+			expected: "(min'1' || equ'')",
+			wantErr:  false,
+		},
+		{
+			name:     "Valid nested alias with condition",
+			input:    "@password && neq'password'",
+			expected: "(min'8' && max'30' && (rex'[A-Z]')) && neq'password'",
+			wantErr:  false,
+		},
+		{
+			name:     "Valid alias in group",
+			input:    "(@upper || equ'') max5",
+			expected: "((rex'[A-Z]') || equ'') && max'5'",
+			wantErr:  false,
+		},
+		{
+			name:    "Invalid unknown alias",
+			input:   "@unknown",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid alias cycle",
+			input:   "@cycle1",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid self referencing alias",
+			input:   "min1 @self",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid alias requirement",
+			input:   "@invalid",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rootNode, err := parser.ParseValidation(test.input)
+			if test.wantErr {
+				assert.Error(t, err, "Expected an error for input: %s", test.input)
+			} else {
+				assert.NoError(t, err, "Did not expect an error for input: %s", test.input)
+				assert.Equal(t, test.expected, rootNode.RootValue.AstGroupToString(), "Expected expanded requirement to match")
+			}
+		})
+	}
+
+	t.Run("Cycle in error message", func(t *testing.T) {
+		_, err := parser.ParseValidation("@cycle1")
+		assert.ErrorContains(t, err, "@cycle1 -> @cycle2 -> @cycle1", "Expected cycle in error message")
+	})
+}
+
+func TestParserValueLists(t *testing.T) {
+	parser := NewParser().SetValueLists(map[string]model.ValueSet{
+		"plans": model.NewValueSet("free", "pro"),
+	})
+
+	t.Run("Valid value list", func(t *testing.T) {
+		rootNode, err := parser.ParseValidation("frm@plans || nfr@plans")
+		require.NoError(t, err, "Did not expect an error for value list")
+		assert.Equal(t, model.NewValueSet("free", "pro"), rootNode.RootValue.ConditionGroup[0].ParsedValue, "Expected value list as parsed value")
+		assert.Equal(t, model.NewValueSet("free", "pro"), rootNode.RootValue.ConditionGroup[1].ParsedValue, "Expected value list as parsed value")
+	})
+
+	t.Run("Valid @ in other condition", func(t *testing.T) {
+		rootNode, err := parser.ParseValidation("equ@plans")
+		require.NoError(t, err, "Did not expect an error for @ in other condition")
+		assert.Nil(t, rootNode.RootValue.ConditionGroup[0].ParsedValue, "Expected no parsed value")
+	})
+
+	t.Run("Built-in value list", func(t *testing.T) {
+		rootNode, err := parser.ParseValidation("frm@currencies")
+		require.NoError(t, err, "Did not expect an error for built-in value list")
+		valueSet, ok := rootNode.RootValue.ConditionGroup[0].ParsedValue.(model.ValueSet)
+		require.True(t, ok, "Expected value list as parsed value")
+		assert.True(t, valueSet.Contains("EUR"), "Expected built-in currencies")
+	})
+
+	t.Run("Value list replacing built-in value list", func(t *testing.T) {
+		rootNode, err := NewParser().SetValueLists(map[string]model.ValueSet{
+			"currencies": model.NewValueSet("EUR"),
+		}).ParseValidation("frm@currencies")
+		require.NoError(t, err, "Did not expect an error for value list")
+		assert.Equal(t, model.NewValueSet("EUR"), rootNode.RootValue.ConditionGroup[0].ParsedValue, "Expected set value list as parsed value")
+	})
+
+	t.Run("Invalid unknown value list", func(t *testing.T) {
+		_, err := parser.ParseValidation("frm@unknown")
+		assert.ErrorContains(t, err, "unknown value list: @unknown", "Expected unknown value list error")
+	})
+}
+
+func TestParserEnumValues(t *testing.T) {
+	t.Run("Enum values as parsed value", func(t *testing.T) {
+		parser := NewParser().SetEnumValues(model.NewValueSet("active", "inactive"))
+		rootNode, err := parser.ParseValidation("enum")
+		require.NoError(t, err, "Did not expect an error for enum")
+		assert.Equal(t, model.NewValueSet("active", "inactive"), rootNode.RootValue.ConditionGroup[0].ParsedValue, "Expected enum values as parsed value")
+	})
+
+	t.Run("Enum without values", func(t *testing.T) {
+		rootNode, err := NewParser().ParseValidation("enum")
+		require.NoError(t, err, "Did not expect an error for enum")
+		assert.Nil(t, rootNode.RootValue.ConditionGroup[0].ParsedValue, "Expected no parsed value")
+	})
+}
+
+func TestParserErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []model.ParseError
+	}{
+		{
+			name:  "Invalid condition type",
+			input: "min1 && mux5",
+			expected: []model.ParseError{
+				{Found: "mux", Line: 1, Column: 9, Start: 8, End: 11},
+			},
+		},
+		{
+			name:  "Multiple errors in one requirement",
+			input: "mux5 || (minabc && equ'a) ||",
+			expected: []model.ParseError{
+				{Found: "mux", Line: 1, Column: 1, Start: 0, End: 3},
+				{Found: "abc", Line: 1, Column: 13, Start: 12, End: 15},
+				{Expected: "condition value", Found: "'a) ||", Line: 1, Column: 23, Start: 22, End: 28},
+				{Expected: "right brace", Found: "end of requirement", Line: 1, Column: 29, Start: 28, End: 28},
+			},
+		},
+		{
+			name:  "Errors in multiple lines",
+			input: "(min1 ||\n max2 ?)\n&& )",
+			expected: []model.ParseError{
+				{Expected: "right brace, condition, alias or operator", Found: "?", Line: 2, Column: 7, Start: 15, End: 16},
+				{Expected: "condition, alias or operator", Found: ")", Line: 3, Column: 4, Start: 21, End: 22},
+			},
+		},
+		{
+			name:  "Escape at end of requirement",
+			input: `fun:a("\`,
+			expected: []model.ParseError{
+				{Expected: "condition value", Found: `:a("\`, Line: 1, Column: 4, Start: 3, End: 8},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewParser().ParseValidation(test.input)
+			require.Error(t, err, "Expected an error for input: %s", test.input)
+
+			var parseErrors model.ParseErrors
+			require.ErrorAs(t, err, &parseErrors, "Expected parse errors")
+			require.Len(t, parseErrors, len(test.expected), "Expected number of errors to match, got: %v", err)
+			for i, expected := range test.expected {
+				assert.Equal(t, test.input, parseErrors[i].Requirement, "Expected requirement in error")
+				assert.NotEmpty(t, parseErrors[i].Message, "Expected error message")
+				assert.Equal(t, expected.Expected, parseErrors[i].Expected, "Expected expected token to match")
+				assert.Equal(t, expected.Found, parseErrors[i].Found, "Expected found token to match")
+				assert.Equal(t, expected.Line, parseErrors[i].Line, "Expected line to match")
+				assert.Equal(t, expected.Column, parseErrors[i].Column, "Expected column to match")
+				assert.Equal(t, expected.Start, parseErrors[i].Start, "Expected start to match")
+				assert.Equal(t, expected.End, parseErrors[i].End, "Expected end to match")
+			}
+		})
+	}
+
+	t.Run("Snippet of error", func(t *testing.T) {
+		_, err := NewParser().ParseValidation("min1 && mux5")
+		var parseErrors model.ParseErrors
+		require.ErrorAs(t, err, &parseErrors, "Expected parse errors")
+		assert.Equal(t, "min1 && mux5\n        ^^^", parseErrors[0].Snippet(), "Expected snippet to match")
+	})
+}
+
+func TestParserFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Spacing and implicit operators",
+			input:    "min1   max5\n||equ3",
+			expected: "min1 && max5 || equ3",
+		},
+		{
+			name:     "Minimal parentheses",
+			input:    "((min1 && max5)) && (equ3 && (neq4)) && ((con1 || nco2))",
+			expected: "min1 && max5 && equ3 && neq4 && (con1 || nco2)",
+		},
+		{
+			name:     "Parentheses of mixed groups",
+			input:    "(min1 || max5) && (equ3 && neq4 || con5)",
+			expected: "(min1 || max5) && (equ3 && neq4 || con5)",
+		},
+		{
+			name:     "Quoted values",
+			input:    "equ'a b' || rex'^(a|b)$' || con'/'a/''",
+			expected: `equ"a b" || rex^(a|b)$ || con"'a'"`,
+		},
+		{
+			name:     "Lists and function calls",
+			input:    "frm[ 'a',1, 2.0 ] && fun:near( 52.5 , 'km' ) && funcheck",
+			expected: `frm["a", 1, 2.0] && fun:near(52.5, "km") && fun:check`,
+		},
+		{
+			name:     "Empty requirement",
+			input:    "-",
+			expected: "-",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rootNode, err := NewParser().ParseValidation(test.input)
+			require.NoError(t, err, "Did not expect an error for input: %s", test.input)
+			assert.Equal(t, test.expected, rootNode.Format(), "Expected formatted requirement to match")
+
+			formatted, err := NewParser().ParseValidation(test.expected)
+			require.NoError(t, err, "Did not expect an error for formatted requirement: %s", test.expected)
+			assert.Equal(t, test.expected, formatted.Format(), "Expected canonical requirement to be formatted as it is")
+		})
+	}
+}
+
+// FuzzParserFormat checks that every parsable requirement is formatted into a canonical requirement,
+// which can be parsed again into the same conditions with the same evaluation (see checkFormatRoundTrip).
+func FuzzParserFormat(f *testing.F) {
+	seeds := []string{
+		"-",
+		"min1 && max5",
+		"(min1 || max5) && (equ3 && neq4 || con5)",
+		"equ'a b' || rex'^(a|b)$' || con'/'a/''",
+		`frm[ 'a',1, 2.0, "b\"c" ] && fun:near( 52.5 , 'km' ) && funcheck && fun:check()`,
+		"((min1 &&) || (max2)) || ()",
+		"nfr[true, -1.5e3] enum",
+		`frm["\`,
+		`fun:a("\`,
+	}
+	seeds = append(seeds, generateRequirements(50)...)
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, requirement string) {
+		rootNode, err := NewParser().ParseValidation(requirement)
+		if err != nil {
+			return
+		}
+		checkFormatRoundTrip(t, rootNode)
+	})
+}
+
+// checkFormatRoundTrip checks that the formatted requirement of the root node can be parsed again,
+// is formatted the same way, has the same conditions in the same order and the same evaluation
+// for all results of the conditions.
+func checkFormatRoundTrip(t *testing.T, rootNode model.RootNode) {
+	formatted := rootNode.Format()
+	formattedNode, err := NewParser().ParseValidation(formatted)
+	require.NoError(t, err, "Did not expect an error for formatted requirement: %s", formatted)
+	require.Equal(t, formatted, formattedNode.Format(), "Expected formatted requirement to be canonical")
+
+	conditions := flattenConditions(rootNode.RootValue)
+	require.Equal(t, conditions, flattenConditions(formattedNode.RootValue), "Expected same conditions for: %s", formatted)
+
+	combinations := 1 << min(len(conditions), 10)
+	for mask := 0; mask < combinations; mask++ {
+		expected := evaluateGroup(rootNode.RootValue, mask, new(int))
+		actual := evaluateGroup(formattedNode.RootValue, mask, new(int))
+		require.Equal(t, expected, actual, "Expected same evaluation of %s for condition results %b", formatted, mask)
+	}
+}
+
+// flattenConditions returns the debug strings of all conditions in the order of the requirement.
+func flattenConditions(astValue *model.AstValue) []string {
+	conditions := []string{}
+	for _, v := range astValue.ConditionGroup {
+		if v.Type == model.GROUP {
+			conditions = append(conditions, flattenConditions(v)...)
+		} else {
+			condition := *v
+			condition.Operator = ""
+			conditions = append(conditions, condition.AstConditionToString())
+		}
+	}
+	return conditions
+}
+
+// evaluateGroup evaluates the group like Validator.RunValidatorsOnConditionGroup, the result
+// of the n-th condition is the n-th bit of the mask (conditions after the 10th are successful).
+func evaluateGroup(astValue *model.AstValue, mask int, index *int) bool {
+	if astValue.Type == model.EMPTY {
+		return true
+	}
+
+	errors := 0
+	for i, v := range astValue.ConditionGroup {
+		var ok bool
+		if v.Type == model.GROUP {
+			ok = evaluateGroup(v, mask, index)
+		} else {
+			ok = *index >= 10 || mask&(1<<*index) != 0
+			*index++
+		}
+		if ok {
+			continue
+		}
+		if (i == 0 && v.Operator == model.OR) || (i > 0 && astValue.ConditionGroup[i-1].Operator == model.OR) {
+			errors++
+		} else {
+			// skip the conditions of the not evaluated rest of the group
+			*index += len(flattenConditions(&model.AstValue{ConditionGroup: astValue.ConditionGroup[i+1:]}))
+			return false
+		}
+	}
+	return len(astValue.ConditionGroup) == 0 || errors < len(astValue.ConditionGroup)
+}
+
+// generateRequirements returns n pseudo random requirements with nested groups, operators and values.
+func generateRequirements(n int) []string {
+	random := rand.New(rand.NewSource(1))
+	conditions := []string{"min1", "max-2.5", "equa", "neq'a b'", `con"x\ty"`, "rex^(a|b)$", "frm['a', 1]", "nfr@", "fun:check(1, 2.0)", "funcheck", "enum"}
+	var generate func(depth int) string
+	generate = func(depth int) string {
+		items := []string{}
+		for i := 0; i < 1+random.Intn(3); i++ {
+			item := conditions[random.Intn(len(conditions))]
+			if depth < 3 && random.Intn(3) == 0 {
+				item = "(" + generate(depth+1) + ")"
+			}
+			items = append(items, item)
+			if random.Intn(4) > 0 {
+				items = append(items, []string{"&&", "||"}[random.Intn(2)])
+			}
+		}
+		return strings.Join(items, " ")
+	}
+
+	requirements := []string{}
+	for i := 0; i < n; i++ {
+		requirements = append(requirements, generate(0))
+	}
+	return requirements
+}
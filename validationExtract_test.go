@@ -1,10 +1,12 @@
 package validator
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/siherrmann/validator/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetValidationsFromStruct(t *testing.T) {
@@ -63,6 +65,156 @@ func TestGetValidationsFromStruct(t *testing.T) {
 			},
 			expectedError: false,
 		},
+		{
+			name: "Valid struct with function arguments and groups",
+			args: args{
+				input: &struct {
+					Field1 float64 `vld:"fun:near(52.5, 13.4, 10), gr1min1"`
+					Field2 string  `vld:"fun:prefix('a, b')"`
+				}{},
+				tagType: model.VLD,
+			},
+			expected: []model.Validation{
+				{Key: "Field1", Type: model.Float, Requirement: "fun:near(52.5, 13.4, 10)", Groups: []*model.Group{{Name: "gr1", ConditionType: "min", ConditionValue: "1"}}},
+				{Key: "Field2", Type: model.String, Requirement: "fun:prefix('a, b')"},
+			},
+			expectedError: false,
+		},
+		{
+			name: "Valid struct with enum",
+			args: args{
+				input: &struct {
+					Status testStatus   `json:"status" vld:"enum"`
+					Labels []testStatus `json:"labels" vld:"enum"`
+				}{},
+				tagType: model.VLD,
+			},
+			expected: []model.Validation{
+				{Key: "status", Type: model.String, Requirement: "enum", Enum: []any{testStatusActive, testStatusInactive}},
+				{Key: "labels", Type: model.Array, Requirement: "enum", Enum: []any{testStatusActive, testStatusInactive}},
+			},
+			expectedError: false,
+		},
+		{
+			name: "Valid struct with named tag sections",
+			args: args{
+				input: &struct {
+					Name  string `json:"name" vld:"req=min3; groups=gr1min1; default=guest; msg=name is too short"`
+					Code  string `json:"code" vld:"rex'^[a-z]+, [a-z]+$'"`
+					Short string `json:"short" vld:"min1"`
+				}{},
+				tagType: model.VLD,
+			},
+			expected: []model.Validation{
+				{Key: "name", Type: model.String, Requirement: "min3", Groups: []*model.Group{{Name: "gr1", ConditionType: "min", ConditionValue: "1"}}, Default: "guest", Message: "name is too short"},
+				{Key: "code", Type: model.String, Requirement: "rex'^[a-z]+, [a-z]+$'"},
+				{Key: "short", Type: model.String, Requirement: "min1"},
+			},
+			expectedError: false,
+		},
+		{
+			name: "Invalid struct with invalid named tag",
+			args: args{
+				input: &struct {
+					Name string `json:"name" vld:"groups=gr1min1"`
+				}{},
+				tagType: model.VLD,
+			},
+			expected:      []model.Validation{},
+			expectedError: true,
+		},
+		{
+			name: "Invalid struct with invalid requirement",
+			args: args{
+				input: &struct {
+					Short string `json:"short" vld:"min"`
+				}{},
+				tagType: model.VLD,
+			},
+			expected:      []model.Validation{},
+			expectedError: true,
+		},
+		{
+			name: "Invalid struct with condition values not convertible to the field type",
+			args: args{
+				input: &struct {
+					Count int      `json:"count" vld:"equabc"`
+					Tags  []int    `json:"tags" vld:"con5 || conabc"`
+					Sizes []string `json:"sizes" vld:"equtwo"`
+				}{},
+				tagType: model.VLD,
+			},
+			expected:      []model.Validation{},
+			expectedError: true,
+		},
+		{
+			name: "Valid struct with condition values convertible to the field type",
+			args: args{
+				input: &struct {
+					Count int            `json:"count" vld:"equ5 || frm1,2"`
+					Tags  []int          `json:"tags" vld:"con5 && nfr1,2"`
+					Keys  map[string]int `json:"keys" vld:"equ2 && conabc"`
+					Flag  bool           `json:"flag" vld:"neqfalse"`
+				}{},
+				tagType: model.VLD,
+			},
+			expected: []model.Validation{
+				{Key: "count", Type: model.Int, Requirement: "equ5 || frm1,2"},
+				{Key: "tags", Type: model.Array, Requirement: "con5 && nfr1,2"},
+				{Key: "keys", Type: model.Map, Requirement: "equ2 && conabc"},
+				{Key: "flag", Type: model.Bool, Requirement: "neqfalse"},
+			},
+			expectedError: false,
+		},
+		{
+			name: "Invalid struct with inconsistent groups",
+			args: args{
+				input: &struct {
+					Field1 string `vld:"min1, gr1min1"`
+					Field2 string `vld:"min1, gr1max1"`
+				}{},
+				tagType: model.VLD,
+			},
+			expected:      []model.Validation{},
+			expectedError: true,
+		},
+		{
+			name: "Invalid struct with group needing more fields",
+			args: args{
+				input: &struct {
+					Field1 string `vld:"min1, gr1min2"`
+				}{},
+				tagType: model.VLD,
+			},
+			expected:      []model.Validation{},
+			expectedError: true,
+		},
+		{
+			name: "Valid struct with source tag",
+			args: args{
+				input: &struct {
+					ID        int    `json:"id" src:"path" vld:"min1"`
+					RequestID string `json:"request_id" src:"header:X-Request-ID" vld:"-"`
+				}{},
+				tagType: model.VLD,
+			},
+			expected: []model.Validation{
+				{Key: "id", Type: model.Int, Requirement: "min1", Source: model.SourcePath},
+				{Key: "request_id", Type: model.String, Requirement: "-", Source: model.SourceHeader, SourceName: "X-Request-ID"},
+			},
+			expectedError: false,
+		},
+		{
+			name: "Invalid struct with invalid source",
+			args: args{
+				input: &struct {
+					ID int `json:"id" src:"body" vld:"min1"`
+				}{},
+				tagType: model.VLD,
+			},
+			expected:      []model.Validation{},
+			expectedError: true,
+		},
 		{
 			name: "Valid struct with inner struct",
 			args: args{
@@ -157,7 +309,7 @@ func TestGetValidationsFromStruct(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			validations, err := GetValidationsFromStruct(test.args.input, test.args.tagType)
+			validations, err := NewValidator().GetValidationsFromStruct(test.args.input, test.args.tagType)
 			if test.expectedError {
 				assert.Error(t, err, "Expected error when getting validations")
 			} else {
@@ -167,3 +319,82 @@ func TestGetValidationsFromStruct(t *testing.T) {
 		})
 	}
 }
+
+func TestGetValidationsFromStructChangedRequirement(t *testing.T) {
+	type user struct {
+		Name string `json:"name" vld:"min3"`
+	}
+
+	validations, err := NewValidator().GetValidationsFromStruct(&user{}, model.VLD)
+	require.NoError(t, err, "Expected no error getting validations")
+
+	validations[0].Requirement = "min5"
+	err = NewValidator().ValidateValueWithParser("abcd", &validations[0])
+	assert.Error(t, err, "Expected validation with the changed requirement")
+}
+
+func TestGetValidationsFromStructErrors(t *testing.T) {
+	type invalid struct {
+		Count  int    `json:"count" vld:"equabc"`
+		Name   string `json:"name" vld:"mux3"`
+		Phone  string `json:"phone" vld:"min1, gr1min1"`
+		Email  string `json:"email" vld:"min1, gr1max1"`
+		Street string `json:"street" vld:"equ'a"`
+	}
+
+	_, err := NewValidator().GetValidationsFromStruct(&invalid{}, model.VLD)
+	require.Error(t, err, "Expected error for invalid struct")
+	assert.ErrorContains(t, err, "field Count: condition equ'abc': value abc is not convertible to int", "Expected type error")
+	assert.ErrorContains(t, err, "field Name: line 1, column 1", "Expected parse error with position")
+	assert.ErrorContains(t, err, "field Street: line 1, column 4", "Expected parse error with position")
+	assert.ErrorContains(t, err, "inconsistent group gr1: min1 on field phone, but max1 on field email", "Expected group error")
+
+	var parseErrors model.ParseErrors
+	assert.ErrorAs(t, err, &parseErrors, "Expected parse errors to be wrapped")
+
+	t.Run("Requirements with settings of the validator", func(t *testing.T) {
+		type user struct {
+			Password string `json:"password" vld:"@password"`
+		}
+		v := NewValidator()
+		_, err := v.GetValidationsFromStruct(&user{}, model.VLD)
+		assert.ErrorContains(t, err, "unknown alias: @password", "Expected error for unknown alias")
+
+		require.NoError(t, v.DefineAlias("password", "min8"), "Expected no error defining alias")
+		validations, err := v.GetValidationsFromStruct(&user{}, model.VLD)
+		require.NoError(t, err, "Expected no error with alias of the validator")
+		assert.Equal(t, []model.Validation{{Key: "password", Type: model.String, Requirement: "@password"}}, validations, "Expected validations to match")
+	})
+}
+
+func TestGetValidationsFromStructWrapper(t *testing.T) {
+	type payment struct {
+		Password string `json:"password" vld:"@password"`
+		Currency string `json:"currency" vld:"currencyEUR,USD"`
+		Country  string `json:"country" vld:"frm@shops"`
+		Count    int    `json:"count" vld:"equabc"`
+	}
+
+	validations, err := GetValidationsFromStruct(&payment{}, model.VLD)
+	require.NoError(t, err, "Expected wrapper not to check requirements which need the settings of a validator")
+	assert.Equal(t, []model.Validation{
+		{Key: "password", Type: model.String, Requirement: "@password"},
+		{Key: "currency", Type: model.String, Requirement: "currencyEUR,USD"},
+		{Key: "country", Type: model.String, Requirement: "frm@shops"},
+		{Key: "count", Type: model.Int, Requirement: "equabc"},
+	}, validations, "Expected validations to match")
+
+	fieldType, _ := reflect.TypeOf(payment{}).FieldByName("Currency")
+	validation, err := GetValidationFromStructField(model.VLD, reflect.ValueOf(payment{}).FieldByName("Currency"), fieldType)
+	require.NoError(t, err, "Expected wrapper not to check the requirement of the field")
+	assert.Equal(t, "currencyEUR,USD", validation.Requirement, "Expected requirement of the field")
+
+	t.Run("Groups are checked", func(t *testing.T) {
+		type invalid struct {
+			Phone string `json:"phone" vld:"min1, gr1min1"`
+			Email string `json:"email" vld:"min1, gr1max1"`
+		}
+		_, err := GetValidationsFromStruct(&invalid{}, model.VLD)
+		assert.ErrorContains(t, err, "inconsistent group gr1", "Expected group error")
+	})
+}
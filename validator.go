@@ -1,9 +1,13 @@
 package validator
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"regexp"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/siherrmann/validator/helper"
 	"github.com/siherrmann/validator/model"
@@ -11,18 +15,117 @@ import (
 	"github.com/siherrmann/validator/validators"
 )
 
+var conditionTypeNameRegex = regexp.MustCompile(`^[a-z][a-zA-Z]{2,}$`)
+var aliasNameRegex = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
 type ValidationFunc func(input any, astValue *model.AstValue) error
 
+// ValidationFuncCtx is a validation function that gets the context of the validation,
+// eg. to honour request cancellation or to read the tenant of a request.
+type ValidationFuncCtx func(ctx context.Context, input any, astValue *model.AstValue) error
+
+// ConditionValidator validates the input against a condition of a custom condition type.
+// The condition value is in astValue.ConditionValue and the parsed value in astValue.ParsedValue.
+type ConditionValidator func(input any, astValue *model.AstValue) error
+
+// CustomConditionType is a condition type added with AddConditionType.
+type CustomConditionType struct {
+	// Validate validates the input against the condition.
+	Validate ConditionValidator
+	// ParseValue parses and checks the condition value while parsing the requirement, nil accepts any value.
+	ParseValue model.ConditionValueParser
+	// Description describes the condition type (eg. `ISO 4217 currency code from the list`).
+	Description string
+}
+
+// Decoder decodes the body of a request into a JsonMap.
+// Decoders can be added for additional media types with AddDecoder.
+type Decoder func(request *http.Request) (map[string]any, error)
+
 // Validator is the main struct for validation.
 type Validator struct {
 	ValidationFuncs map[string]ValidationFunc
+	// ValidationFuncsCtx are the context aware validation functions, they are used before
+	// ValidationFuncs with the same name.
+	ValidationFuncsCtx map[string]ValidationFuncCtx
+	// ValidationFuncTimeouts are the timeouts of the context aware validation functions by name.
+	ValidationFuncTimeouts map[string]time.Duration
+	// ConditionTypes are the custom condition types by name, added with AddConditionType.
+	ConditionTypes map[model.ConditionType]CustomConditionType
+	// Aliases are named requirements by name, added with DefineAlias and referenced with `@name`.
+	Aliases map[string]string
+	// ValueLists are named value lists by name, added with AddValueList and referenced
+	// in `frm` and `nfr` conditions with `@name`. They are used before the built-in value lists.
+	ValueLists map[string]model.ValueSet
+	// ValidationFuncArities are the number of arguments of the validation functions by name
+	// (eg. 1 for `fun:divisible(5)`). Functions without arity take no arguments.
+	ValidationFuncArities map[string]int
+	// MergeStrategy is used by ValidateAndUpdateWithValidation to merge the validated values
+	// into the map to update. An empty strategy is handled like model.MergeReplace.
+	MergeStrategy model.MergeStrategy
+	// MergeKey is the key used to match maps in arrays with model.MergeArraysByKey (eg. `id`).
+	MergeKey string
+	// MaxFormIndex is the highest array index allowed in form keys like `items[0].name`.
+	// A value <= 0 uses helper.DefaultMaxFormIndex.
+	MaxFormIndex int
+	// Decoders are additional decoders by media type (eg. `application/xml`).
+	Decoders map[string]Decoder
+	// Limits are the limits for decoding request bodies, the zero value is unlimited.
+	// Exceeded limits return an error wrapping model.ErrLimitExceeded.
+	Limits model.Limits
+	// ErrorResponder writes the response if Bind or Handler fail, nil uses DefaultErrorResponder.
+	ErrorResponder ErrorResponder
+	// ValidationStatus is the status code for invalid input in Bind and Handler
+	// (eg. http.StatusUnprocessableEntity), 0 uses http.StatusBadRequest.
+	ValidationStatus int
+	// ErrorStatus returns the status code for errors returned by functions of Handler,
+	// nil or 0 uses http.StatusInternalServerError.
+	ErrorStatus func(err error) int
 }
 
 // NewValidator creates a new Validator instance with an empty validation functions map.
 func NewValidator() *Validator {
 	return &Validator{
-		ValidationFuncs: make(map[string]ValidationFunc),
+		ValidationFuncs:        make(map[string]ValidationFunc),
+		ValidationFuncsCtx:     make(map[string]ValidationFuncCtx),
+		ValidationFuncTimeouts: make(map[string]time.Duration),
+		ValidationFuncArities:  make(map[string]int),
+		ConditionTypes:         make(map[model.ConditionType]CustomConditionType),
+		Aliases:                make(map[string]string),
+		ValueLists:             make(map[string]model.ValueSet),
+		MergeStrategy:          model.MergeReplace,
+		MaxFormIndex:           helper.DefaultMaxFormIndex,
+		Decoders:               make(map[string]Decoder),
+	}
+}
+
+// AddDecoder adds a decoder for the given media type (eg. `application/xml`) to the Validator.
+// It is used by the UnmapOrUnmarshal functions if the Content-Type of the request matches the media type.
+// Decoders for application/json and form media types replace the default decoding.
+func (r *Validator) AddDecoder(decoder Decoder, mediaType string) {
+	if r.Decoders == nil {
+		r.Decoders = make(map[string]Decoder)
+	}
+	r.Decoders[strings.ToLower(mediaType)] = decoder
+}
+
+// SetMergeStrategy sets the strategy used to merge validated values into the map to update.
+// The mergeKey is only needed for model.MergeArraysByKey.
+func (r *Validator) SetMergeStrategy(strategy model.MergeStrategy, mergeKey ...string) error {
+	err := model.LookupMergeStrategy(strategy)
+	if err != nil {
+		return err
 	}
+	if strategy == model.MergeArraysByKey && (len(mergeKey) == 0 || len(mergeKey[0]) == 0) {
+		return fmt.Errorf("merge key is required for merge strategy %s", strategy)
+	}
+
+	r.MergeStrategy = strategy
+	r.MergeKey = ""
+	if len(mergeKey) > 0 {
+		r.MergeKey = mergeKey[0]
+	}
+	return nil
 }
 
 // AddValidationFunc adds a custom validation function to the Validator.
@@ -31,10 +134,172 @@ func (r *Validator) AddValidationFunc(fn ValidationFunc, name string) {
 	r.ValidationFuncs[name] = fn
 }
 
+// AddValidationFuncCtx adds a context aware validation function to the Validator.
+// The function can be used in validation requirements with the name provided (`fun<name>`)
+// and gets the context of the ...Context methods (or of the request for the Unmap/Unmarshal methods).
+// If a timeout > 0 is given, the context of the function is canceled after the timeout.
+func (r *Validator) AddValidationFuncCtx(fn ValidationFuncCtx, name string, timeout ...time.Duration) {
+	if r.ValidationFuncsCtx == nil {
+		r.ValidationFuncsCtx = make(map[string]ValidationFuncCtx)
+	}
+	r.ValidationFuncsCtx[name] = fn
+
+	if len(timeout) > 0 && timeout[0] > 0 {
+		r.SetValidationFuncTimeout(name, timeout[0])
+	}
+}
+
+// SetValidationFuncTimeout sets the timeout of the context aware validation function with the given name.
+// A timeout <= 0 removes the timeout.
+func (r *Validator) SetValidationFuncTimeout(name string, timeout time.Duration) {
+	if r.ValidationFuncTimeouts == nil {
+		r.ValidationFuncTimeouts = make(map[string]time.Duration)
+	}
+	if timeout <= 0 {
+		delete(r.ValidationFuncTimeouts, name)
+		return
+	}
+	r.ValidationFuncTimeouts[name] = timeout
+}
+
+// SetValidationFuncArity sets the number of arguments of the validation function with the given name.
+// Calls like `fun:near(52.5, 13.4, 10)` are checked for the number of arguments while parsing
+// and the typed arguments are passed to the function in astValue.ConditionArgs.
+// A negative arity allows any number of arguments.
+func (r *Validator) SetValidationFuncArity(name string, arity int) {
+	if r.ValidationFuncArities == nil {
+		r.ValidationFuncArities = make(map[string]int)
+	}
+	r.ValidationFuncArities[name] = arity
+}
+
+// AddConditionType adds a custom condition type to the Validator, which is used in requirements
+// like the built-in condition types (eg. `currencyEUR,USD` for a condition type `currency`).
+// The name has to consist of at least 3 letters in camel case (eg. `isoCode`) and must not be a built-in condition type.
+// If the name starts with a built-in condition type the longest matching condition type is used
+// (eg. `minimum5` is the custom condition type `minimum` and `min5` is still `min`).
+func (r *Validator) AddConditionType(name model.ConditionType, conditionType CustomConditionType) error {
+	if !conditionTypeNameRegex.MatchString(string(name)) {
+		return fmt.Errorf("invalid condition type name %s, expected at least 3 letters in camel case", name)
+	}
+	if model.LookupConditionType(name) == nil {
+		return fmt.Errorf("condition type %s is a built-in condition type", name)
+	}
+	if conditionType.Validate == nil {
+		return fmt.Errorf("condition type %s has no validate function", name)
+	}
+
+	if r.ConditionTypes == nil {
+		r.ConditionTypes = make(map[model.ConditionType]CustomConditionType)
+	}
+	r.ConditionTypes[name] = conditionType
+	return nil
+}
+
+// DefineAlias defines a named requirement, which can be referenced in requirements with `@name`
+// (eg. `vld:"@password"` or `vld:"@password && neq'password'"`). The alias is expanded as a group
+// while parsing and can reference other aliases, also aliases defined later. Cycles return an error while parsing.
+// The name has to consist of letters, digits and `_`.
+func (r *Validator) DefineAlias(name string, requirement string) error {
+	if !aliasNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid alias name %s, expected letters, digits and _", name)
+	}
+	if len(strings.TrimSpace(requirement)) == 0 || strings.TrimSpace(requirement) == string(model.NONE) {
+		return fmt.Errorf("alias %s needs a requirement with at least one condition", name)
+	}
+
+	if r.Aliases == nil {
+		r.Aliases = make(map[string]string)
+	}
+	r.Aliases[name] = requirement
+	return nil
+}
+
+// AddValueList adds a named value list, which can be referenced in `frm` and `nfr` conditions
+// with `@name` (eg. `frm@plans`). The values are looked up in a set, so long lists stay fast.
+// A value list with the name of a built-in value list (countries, currencies, languages and timezones) replaces it.
+// The name has to consist of letters, digits and `_`.
+func (r *Validator) AddValueList(name string, values ...string) error {
+	if !aliasNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid value list name %s, expected letters, digits and _", name)
+	}
+	if len(values) == 0 {
+		return fmt.Errorf("value list %s needs at least one value", name)
+	}
+
+	if r.ValueLists == nil {
+		r.ValueLists = make(map[string]model.ValueSet)
+	}
+	r.ValueLists[name] = model.NewValueSet(values...)
+	return nil
+}
+
+// newParser creates a new parser which knows the arities of the validation functions,
+// the custom condition types, the aliases and the value lists.
+func (r *Validator) newParser() *parser.Parser {
+	conditionTypes := map[model.ConditionType]model.ConditionValueParser{}
+	for name, conditionType := range r.ConditionTypes {
+		conditionTypes[name] = conditionType.ParseValue
+	}
+
+	funcArities := map[string]int{}
+	for name := range r.ValidationFuncs {
+		funcArities[name] = 0
+	}
+	for name := range r.ValidationFuncsCtx {
+		funcArities[name] = 0
+	}
+	for name, arity := range r.ValidationFuncArities {
+		funcArities[name] = arity
+	}
+
+	return parser.NewParser().
+		SetFuncArities(funcArities).
+		SetConditionTypes(conditionTypes).
+		SetAliases(r.Aliases).
+		SetValueLists(r.ValueLists)
+}
+
+// parseValidation parses the requirement of the validation with a new parser (see newParser)
+// which knows the enum values of the validation.
+func (r *Validator) parseValidation(validation *model.Validation) (model.RootNode, error) {
+	p := r.newParser()
+	if len(validation.Enum) > 0 {
+		enumValues, err := helper.EnumValueSet(validation.Enum)
+		if err != nil {
+			return model.RootNode{}, err
+		}
+		p.SetEnumValues(enumValues)
+	}
+	return p.ParseValidation(validation.Requirement)
+}
+
+// runValidationFuncCtx runs the context aware validation function with the timeout of the function.
+// It does not run the function if the context is already done.
+func (r *Validator) runValidationFuncCtx(ctx context.Context, fn ValidationFuncCtx, input any, astValue *model.AstValue) error {
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	if timeout, ok := r.ValidationFuncTimeouts[astValue.ConditionValue]; ok && timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return fn(ctx, input, astValue)
+}
+
 // Validate validates a given struct by the given tagType.
 // It checks if the keys are in the struct and validates the values.
 // It returns an error if the validation fails.
 func (r *Validator) Validate(v any, tagType ...string) error {
+	return r.ValidateContext(context.Background(), v, tagType...)
+}
+
+// ValidateContext does the same as Validate, but passes the context
+// to the context aware validation functions (see AddValidationFuncCtx).
+func (r *Validator) ValidateContext(ctx context.Context, v any, tagType ...string) error {
 	tagTypeSet := model.VLD
 	if len(tagType) > 0 {
 		tagTypeSet = tagType[0]
@@ -46,14 +311,14 @@ func (r *Validator) Validate(v any, tagType ...string) error {
 		return fmt.Errorf("error unmapping struct to json map: %v", err)
 	}
 
-	validations, err := GetValidationsFromStruct(v, tagTypeSet)
+	validations, err := r.GetValidationsFromStruct(v, tagTypeSet)
 	if err != nil {
 		return fmt.Errorf("error getting validations from struct: %v", err)
 	}
 
-	_, err = r.ValidateWithValidation(jsonMap, validations)
+	_, err = r.ValidateWithValidationContext(ctx, jsonMap, validations)
 	if err != nil {
-		return fmt.Errorf("error validating struct: %v", err)
+		return fmt.Errorf("error validating struct: %w", err)
 	}
 
 	return nil
@@ -63,40 +328,94 @@ func (r *Validator) Validate(v any, tagType ...string) error {
 // It checks if the keys are in the map, validates the values and updates the struct if the validation passes.
 // It returns an error if the validation fails or if the struct cannot be updated.
 func (r *Validator) ValidateAndUpdate(jsonInput map[string]any, structToUpdate any, tagType ...string) error {
+	return r.ValidateAndUpdateContext(context.Background(), jsonInput, structToUpdate, tagType...)
+}
+
+// ValidateAndUpdateContext does the same as ValidateAndUpdate, but passes the context
+// to the context aware validation functions (see AddValidationFuncCtx).
+func (r *Validator) ValidateAndUpdateContext(ctx context.Context, jsonInput map[string]any, structToUpdate any, tagType ...string) error {
 	tagTypeSet := model.VLD
 	if len(tagType) > 0 {
 		tagTypeSet = tagType[0]
 	}
 
-	validations, err := GetValidationsFromStruct(structToUpdate, tagTypeSet)
+	validations, err := r.GetValidationsFromStruct(structToUpdate, tagTypeSet)
 	if err != nil {
 		return fmt.Errorf("error getting validations from struct: %v", err)
 	}
 
-	validatedMap, err := r.ValidateWithValidation(jsonInput, validations)
+	validatedMap, err := r.ValidateWithValidationContext(ctx, jsonInput, validations)
 	if err != nil {
-		return fmt.Errorf("error validating struct: %v", err)
+		return fmt.Errorf("error validating struct: %w", err)
 	}
 
 	err = helper.MapJsonMapToStruct(validatedMap, structToUpdate)
 	if err != nil {
-		return fmt.Errorf("error mapping json map to struct: %v", err)
+		return &model.InputError{Err: fmt.Errorf("error mapping json map to struct: %v", err)}
 	}
 
 	return nil
 }
 
+// ValidateAndUpdateWithChanges does the same as ValidateAndUpdate, but returns the changes
+// made to the struct (field path, old value and new value).
+// The changes are recorded while the validated values are written into the struct,
+// so unchanged values are not part of the ChangeSet.
+// It returns an error if the validation fails or if the struct cannot be updated.
+func (r *Validator) ValidateAndUpdateWithChanges(jsonInput map[string]any, structToUpdate any, tagType ...string) (model.ChangeSet, error) {
+	return r.ValidateAndUpdateWithChangesContext(context.Background(), jsonInput, structToUpdate, tagType...)
+}
+
+// ValidateAndUpdateWithChangesContext does the same as ValidateAndUpdateWithChanges, but passes the context
+// to the context aware validation functions (see AddValidationFuncCtx).
+func (r *Validator) ValidateAndUpdateWithChangesContext(ctx context.Context, jsonInput map[string]any, structToUpdate any, tagType ...string) (model.ChangeSet, error) {
+	tagTypeSet := model.VLD
+	if len(tagType) > 0 {
+		tagTypeSet = tagType[0]
+	}
+
+	validations, err := r.GetValidationsFromStruct(structToUpdate, tagTypeSet)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validations from struct: %v", err)
+	}
+
+	validatedMap, err := r.ValidateWithValidationContext(ctx, jsonInput, validations)
+	if err != nil {
+		return nil, fmt.Errorf("error validating struct: %w", err)
+	}
+
+	changes, err := helper.MapJsonMapToStructWithChanges(validatedMap, structToUpdate)
+	if err != nil {
+		return nil, &model.InputError{Err: fmt.Errorf("error mapping json map to struct: %v", err)}
+	}
+
+	return changes, nil
+}
+
 // ValidateAndUpdateWithValidation validates a given JsonMap by the given validations and updates the map.
 // It checks if the keys are in the map, validates the values and updates the map if the validation passes.
+// The values are merged into the map with the MergeStrategy of the Validator (see SetMergeStrategy),
+// nested maps and arrays of maps are only merged key by key if they have an InnerValidation.
 // It returns an error if the validation fails or if the map cannot be updated.
 func (r *Validator) ValidateAndUpdateWithValidation(jsonInput map[string]any, mapToUpdate *map[string]any, validations []model.Validation) error {
-	validatedValues, err := r.ValidateWithValidation(jsonInput, validations)
+	return r.ValidateAndUpdateWithValidationContext(context.Background(), jsonInput, mapToUpdate, validations)
+}
+
+// ValidateAndUpdateWithValidationContext does the same as ValidateAndUpdateWithValidation, but passes the context
+// to the context aware validation functions (see AddValidationFuncCtx).
+func (r *Validator) ValidateAndUpdateWithValidationContext(ctx context.Context, jsonInput map[string]any, mapToUpdate *map[string]any, validations []model.Validation) error {
+	validatedValues, err := r.ValidateWithValidationContext(ctx, jsonInput, validations)
 	if err != nil {
-		return fmt.Errorf("error validating json map: %v", err)
+		return fmt.Errorf("error validating json map: %w", err)
 	}
 
-	for k, v := range validatedValues {
-		(*mapToUpdate)[k] = v
+	if *mapToUpdate == nil {
+		*mapToUpdate = map[string]any{}
+	}
+
+	err = helper.MergeJsonMap(*mapToUpdate, validatedValues, validations, r.MergeStrategy, r.MergeKey)
+	if err != nil {
+		return fmt.Errorf("error merging json map: %v", err)
 	}
 
 	return nil
@@ -111,6 +430,12 @@ func (r *Validator) ValidateAndUpdateWithValidation(jsonInput map[string]any, ma
 //
 // It returns a new JsonMap with the validated values or an error if the validation fails.
 func (r *Validator) ValidateWithValidation(jsonInput map[string]any, validations []model.Validation) (map[string]any, error) {
+	return r.ValidateWithValidationContext(context.Background(), jsonInput, validations)
+}
+
+// ValidateWithValidationContext does the same as ValidateWithValidation, but passes the context
+// to the context aware validation functions (see AddValidationFuncCtx).
+func (r *Validator) ValidateWithValidationContext(ctx context.Context, jsonInput map[string]any, validations []model.Validation) (map[string]any, error) {
 	keys := []string{}
 	groups := map[string]*model.Group{}
 	groupSize := map[string]int{}
@@ -133,14 +458,21 @@ func (r *Validator) ValidateWithValidation(jsonInput map[string]any, validations
 
 		var ok bool
 		var jsonValue any
-		if jsonValue, ok = jsonInput[validation.Key]; !ok {
+		if jsonValue, ok = jsonInput[validation.Key]; !ok && len(validation.Default) > 0 {
+			// The default is converted like a form value, so it has the type of the validation.
+			var err error
+			jsonValue, _, err = helper.FormValuesToType([]string{validation.Default}, validation.Type.ToReflectType())
+			if err != nil {
+				return map[string]any{}, fmt.Errorf("error converting default of %v: %v", validation.Key, err)
+			}
+		} else if !ok {
 			if strings.TrimSpace(validation.Requirement) == string(model.NONE) {
 				continue
 			} else if len(validation.Groups) == 0 {
-				return map[string]any{}, fmt.Errorf("json %v key not in map", validation.Key)
+				return map[string]any{}, &model.ValidationError{Key: validation.Key, Message: validation.Message, Missing: true}
 			} else {
 				for _, group := range validation.Groups {
-					groupErrors[group.Name] = append(groupErrors[group.Name], fmt.Errorf("json %v key not in map", validation.Key))
+					groupErrors[group.Name] = append(groupErrors[group.Name], &model.ValidationError{Key: validation.Key, Message: validation.Message, Missing: true})
 				}
 				continue
 			}
@@ -150,12 +482,12 @@ func (r *Validator) ValidateWithValidation(jsonInput map[string]any, validations
 		switch validation.Type {
 		case model.Struct:
 			if jsonValueMap, ok := jsonValue.(map[string]any); ok {
-				jsonValue, err = r.ValidateWithValidation(jsonValueMap, validation.InnerValidation)
+				jsonValue, err = r.ValidateWithValidationContext(ctx, jsonValueMap, validation.InnerValidation)
 				if err != nil {
-					return map[string]any{}, fmt.Errorf("field %v invalid: %v", validation.Key, err.Error())
+					return map[string]any{}, &model.ValidationError{Key: validation.Key, Message: validation.Message, Err: err}
 				}
 			} else {
-				err = r.ValidateValueWithParser(jsonValue, &validation)
+				err = r.ValidateValueWithParserContext(ctx, jsonValue, &validation)
 			}
 		case model.Array:
 			if helper.IsArray(jsonValue) && len(validation.InnerValidation) > 0 {
@@ -165,35 +497,35 @@ func (r *Validator) ValidateWithValidation(jsonInput map[string]any, validations
 				}
 
 				validatedArray := []any{}
-				for _, jsonValueInner := range jsonArray {
+				for index, jsonValueInner := range jsonArray {
 					jsonValueInnerMap, err := helper.GetValidMap(jsonValueInner)
 					if err != nil {
-						return map[string]any{}, fmt.Errorf("field %v invalid: %v", validation.Key, err.Error())
+						return map[string]any{}, &model.ValidationError{Key: validation.Key, Message: validation.Message, Index: &index, Err: err}
 					}
 
-					validatedInnerMap, err := r.ValidateWithValidation(jsonValueInnerMap, validation.InnerValidation)
+					validatedInnerMap, err := r.ValidateWithValidationContext(ctx, jsonValueInnerMap, validation.InnerValidation)
 					if err != nil {
-						return map[string]any{}, fmt.Errorf("field %v invalid: %v", validation.Key, err.Error())
+						return map[string]any{}, &model.ValidationError{Key: validation.Key, Message: validation.Message, Index: &index, Err: err}
 					}
 					validatedArray = append(validatedArray, validatedInnerMap)
 				}
 				jsonValue = validatedArray
 			} else if helper.IsArray(jsonValue) {
-				err = r.ValidateValueWithParser(jsonValue, &validation)
+				err = r.ValidateValueWithParserContext(ctx, jsonValue, &validation)
 			} else if helper.IsString(jsonValue) {
 				// Check if the value is a string from a url value.
 				jsonValue = []string{jsonValue.(string)}
-				err = r.ValidateValueWithParser(jsonValue, &validation)
+				err = r.ValidateValueWithParserContext(ctx, jsonValue, &validation)
 			}
 		default:
-			err = r.ValidateValueWithParser(jsonValue, &validation)
+			err = r.ValidateValueWithParserContext(ctx, jsonValue, &validation)
 		}
 
 		if err != nil && len(validation.Groups) == 0 {
-			return map[string]any{}, fmt.Errorf("field %v invalid: %v", validation.Key, err.Error())
+			return map[string]any{}, &model.ValidationError{Key: validation.Key, Message: validation.Message, Err: err}
 		} else if err != nil {
 			for _, group := range validation.Groups {
-				groupErrors[group.Name] = append(groupErrors[group.Name], fmt.Errorf("field %v invalid: %v", validation.Key, err.Error()))
+				groupErrors[group.Name] = append(groupErrors[group.Name], &model.ValidationError{Key: validation.Key, Message: validation.Message, Err: err})
 			}
 			continue
 		}
@@ -214,13 +546,18 @@ func (r *Validator) ValidateWithValidation(jsonInput map[string]any, validations
 //
 // It returns an error if the validation fails.
 func (r *Validator) ValidateValueWithParser(input any, validation *model.Validation) error {
-	p := parser.NewParser()
-	v, err := p.ParseValidation(validation.Requirement)
+	return r.ValidateValueWithParserContext(context.Background(), input, validation)
+}
+
+// ValidateValueWithParserContext does the same as ValidateValueWithParser, but passes the context
+// to the context aware validation functions (see AddValidationFuncCtx).
+func (r *Validator) ValidateValueWithParserContext(ctx context.Context, input any, validation *model.Validation) error {
+	v, err := r.parseValidation(validation)
 	if err != nil {
 		return err
 	}
 
-	err = r.RunValidatorsOnConditionGroup(input, v.RootValue)
+	err = r.RunValidatorsOnConditionGroupContext(ctx, input, v.RootValue)
 	if err != nil {
 		return err
 	}
@@ -234,58 +571,121 @@ func (r *Validator) ValidateValueWithParser(input any, validation *model.Validat
 // If the operator is AND, it returns an error if any condition fails.
 // If the operator is OR, it collects all errors and returns them if all conditions fail.
 func (r *Validator) RunValidatorsOnConditionGroup(input any, astValue *model.AstValue) error {
+	return r.RunValidatorsOnConditionGroupContext(context.Background(), input, astValue)
+}
+
+// RunValidatorsOnConditionGroupContext does the same as RunValidatorsOnConditionGroup, but passes the context
+// to the context aware validation functions (see AddValidationFuncCtx).
+func (r *Validator) RunValidatorsOnConditionGroupContext(ctx context.Context, input any, astValue *model.AstValue) error {
+	return r.runConditionGroup(ctx, input, astValue, nil)
+}
+
+// runConditionGroup runs the validators of the group like RunValidatorsOnConditionGroupContext.
+// If trace is not nil, a trace of every condition and group is added to its children
+// and conditions and groups not evaluated because of an error before are added as skipped (see ExplainValue).
+func (r *Validator) runConditionGroup(ctx context.Context, input any, astValue *model.AstValue, trace *model.Trace) error {
 	var errors []error
 	for i, v := range astValue.ConditionGroup {
+		var childTrace *model.Trace
+		if trace != nil {
+			childTrace = model.NewTrace(v)
+			trace.Children = append(trace.Children, childTrace)
+		}
+
 		var err error
 		switch v.Type {
 		case model.EMPTY:
+			childTrace.SetOutcome(nil)
+			trace.AddSkipped(astValue.ConditionGroup[i+1:])
 			return nil
 		case model.GROUP:
-			err = r.RunValidatorsOnConditionGroup(input, v)
+			err = r.runConditionGroup(ctx, input, v, childTrace)
 		case model.CONDITION:
-			switch v.ConditionType {
-			case model.NONE:
-				continue
-			case model.EQUAL:
-				err = validators.ValidateEqual(input, v)
-			case model.NOT_EQUAL:
-				err = validators.ValidateNotEqual(input, v)
-			case model.MIN_VALUE:
-				err = validators.ValidateMin(input, v)
-			case model.MAX_VALUE:
-				err = validators.ValidateMax(input, v)
-			case model.CONTAINS:
-				err = validators.ValidateContains(input, v)
-			case model.NOT_CONTAINS:
-				err = validators.ValidateNotContains(input, v)
-			case model.FROM:
-				err = validators.ValidateFrom(input, v)
-			case model.NOT_FROM:
-				err = validators.ValidateNotFrom(input, v)
-			case model.REGX:
-				err = validators.ValidateRegex(input, v)
-			case model.FUNC:
-				fun, ok := r.ValidationFuncs[v.ConditionValue]
-				if !ok {
-					return fmt.Errorf("unknown validation function: %v", v.ConditionValue)
-				}
-				err = fun(input, v)
-			default:
-				return fmt.Errorf("unknown condition type: %v", v.ConditionType)
+			err = r.runCondition(ctx, input, v)
+			if _, ok := err.(*model.ConditionError); err != nil && !ok {
+				childTrace.SetOutcome(err)
+				trace.AddSkipped(astValue.ConditionGroup[i+1:])
+				return err
 			}
 		}
+		childTrace.SetOutcome(err)
+
 		if err != nil {
 			if (i == 0 && v.Operator == model.OR) || (i > 0 && astValue.ConditionGroup[i-1].Operator == model.OR) {
 				errors = append(errors, err)
 			} else {
+				trace.AddSkipped(astValue.ConditionGroup[i+1:])
 				return err
 			}
 		}
 	}
 
 	if len(astValue.ConditionGroup) > 0 && len(errors) >= len(astValue.ConditionGroup) {
-		return fmt.Errorf("no condition fulfilled, all errors: %v", errors)
+		return &model.ConditionGroupError{Errors: errors, Description: r.describeConditionGroupError(input, astValue)}
 	}
 
 	return nil
 }
+
+// runCondition runs the validator of the condition type of the condition.
+// An invalid input returns a ConditionError, all other errors (eg. an unknown validation function
+// or a canceled context) stop the validation of the requirement.
+func (r *Validator) runCondition(ctx context.Context, input any, v *model.AstValue) error {
+	var err error
+	switch v.ConditionType {
+	case model.NONE:
+		return nil
+	case model.EQUAL:
+		err = validators.ValidateEqual(input, v)
+	case model.NOT_EQUAL:
+		err = validators.ValidateNotEqual(input, v)
+	case model.MIN_VALUE:
+		err = validators.ValidateMin(input, v)
+	case model.MAX_VALUE:
+		err = validators.ValidateMax(input, v)
+	case model.CONTAINS:
+		err = validators.ValidateContains(input, v)
+	case model.NOT_CONTAINS:
+		err = validators.ValidateNotContains(input, v)
+	case model.FROM:
+		err = validators.ValidateFrom(input, v)
+	case model.NOT_FROM:
+		err = validators.ValidateNotFrom(input, v)
+	case model.ENUM:
+		err = validators.ValidateFrom(input, v)
+	case model.REGX:
+		err = validators.ValidateRegex(input, v)
+	case model.FILE_MIN_SIZE:
+		err = validators.ValidateFileMinSize(input, v)
+	case model.FILE_MAX_SIZE:
+		err = validators.ValidateFileMaxSize(input, v)
+	case model.FILE_MIME:
+		err = validators.ValidateFileMime(input, v)
+	case model.FILE_SNIFF:
+		err = validators.ValidateFileSniff(input, v)
+	case model.FILE_NAME:
+		err = validators.ValidateFileName(input, v)
+	case model.FUNC:
+		if funCtx, ok := r.ValidationFuncsCtx[v.ConditionValue]; ok {
+			err = r.runValidationFuncCtx(ctx, funCtx, input, v)
+			if model.IsContextError(err) {
+				return err
+			}
+		} else if fun, ok := r.ValidationFuncs[v.ConditionValue]; ok {
+			err = fun(input, v)
+		} else {
+			return fmt.Errorf("unknown validation function: %v", v.ConditionValue)
+		}
+	default:
+		conditionType, ok := r.ConditionTypes[v.ConditionType]
+		if !ok {
+			return fmt.Errorf("unknown condition type: %v", v.ConditionType)
+		}
+		err = conditionType.Validate(input, v)
+	}
+
+	if err != nil {
+		return &model.ConditionError{ConditionType: v.ConditionType, ConditionValue: v.ConditionValue, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,443 @@
+package validator
+
+import (
+	"fmt"
+	"mime"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/siherrmann/validator/helper"
+	"github.com/siherrmann/validator/model"
+)
+
+// fileConditionTypes are the condition types only for files (*multipart.FileHeader and []*multipart.FileHeader).
+var fileConditionTypes = []model.ConditionType{model.FILE_MIN_SIZE, model.FILE_MAX_SIZE, model.FILE_MIME, model.FILE_SNIFF, model.FILE_NAME}
+
+// inapplicableConditionTypes are the condition types which do not apply to a validator type.
+// Structs are also used for single files and time.Time, so only condition types which never apply are listed.
+var inapplicableConditionTypes = map[model.ValidatorType][]model.ConditionType{
+	model.String: fileConditionTypes,
+	model.Int:    slices.Concat([]model.ConditionType{model.CONTAINS, model.NOT_CONTAINS}, fileConditionTypes),
+	model.Float:  slices.Concat([]model.ConditionType{model.CONTAINS, model.NOT_CONTAINS}, fileConditionTypes),
+	model.Bool:   slices.Concat([]model.ConditionType{model.MIN_VALUE, model.MAX_VALUE, model.CONTAINS, model.NOT_CONTAINS}, fileConditionTypes),
+	model.Map:    fileConditionTypes,
+	model.Struct: {model.CONTAINS, model.NOT_CONTAINS, model.FROM, model.NOT_FROM, model.ENUM, model.REGX},
+}
+
+// AnalyzeRequirement analyses a parsed requirement for a field of the validator type without validating a value.
+// It finds conditions which do not apply to the validator type, invalid condition values
+// and conditions connected with AND which can never be fulfilled together (eg. `min10 && max5`, `equ3 && neq3`
+// or `frma,b && nfra,b`) or are redundant (eg. `min3` in `min3 && min5`).
+// Groups with OR are only checked for their single conditions, conditions of a group are checked
+// together with the conditions of the enclosing groups if all of them are connected with AND.
+func AnalyzeRequirement(rootNode model.RootNode, validatorType model.ValidatorType) model.Diagnostics {
+	return analyzeRequirement(rootNode, validatorType, "")
+}
+
+// analyzeRequirement analyses the requirement like AnalyzeRequirement. If the item type of an array
+// or the key type of a map is given, the values of `con`, `nco`, `frm` and `nfr` are checked against it.
+func analyzeRequirement(rootNode model.RootNode, validatorType model.ValidatorType, itemType model.ValidatorType) model.Diagnostics {
+	a := &analyzer{validatorType: validatorType, itemType: itemType, diagnostics: model.Diagnostics{}}
+	if rootNode.RootValue != nil {
+		a.analyzeGroup(rootNode.RootValue, false)
+	}
+	return a.diagnostics
+}
+
+// AnalyzeValidation parses the requirement of the validation with the settings of the Validator
+// (eg. aliases and custom condition types) and analyses it (see AnalyzeRequirement).
+// It returns an error if the requirement can not be parsed.
+func (r *Validator) AnalyzeValidation(validation *model.Validation) (model.Diagnostics, error) {
+	rootNode, err := r.parseValidation(validation)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing requirement of %v: %w", validation.Key, err)
+	}
+
+	diagnostics := AnalyzeRequirement(rootNode, validation.Type)
+	for i := range diagnostics {
+		diagnostics[i].Key = validation.Key
+	}
+	return diagnostics, nil
+}
+
+// AnalyzeStruct analyses the requirements of all fields of a struct by the given tagType (see AnalyzeValidation),
+// including the fields of inner structs (with keys like `address.city`).
+// It can be used in tests or at startup to find requirements which can never be fulfilled.
+// Invalid tags and condition values not convertible to the type of the field return an error (see GetValidationsFromStruct).
+func (r *Validator) AnalyzeStruct(v any, tagType ...string) (model.Diagnostics, error) {
+	tagTypeSet := model.VLD
+	if len(tagType) > 0 {
+		tagTypeSet = tagType[0]
+	}
+
+	validations, err := r.GetValidationsFromStruct(v, tagTypeSet)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validations from struct: %v", err)
+	}
+	return r.analyzeValidations(validations, "")
+}
+
+func (r *Validator) analyzeValidations(validations []model.Validation, keyPrefix string) (model.Diagnostics, error) {
+	diagnostics := model.Diagnostics{}
+	for _, validation := range validations {
+		validation.Key = keyPrefix + validation.Key
+		validationDiagnostics, err := r.AnalyzeValidation(&validation)
+		if err != nil {
+			return nil, err
+		}
+		diagnostics = append(diagnostics, validationDiagnostics...)
+
+		innerDiagnostics, err := r.analyzeValidations(validation.InnerValidation, validation.Key+".")
+		if err != nil {
+			return nil, err
+		}
+		diagnostics = append(diagnostics, innerDiagnostics...)
+	}
+	return diagnostics, nil
+}
+
+type analyzer struct {
+	validatorType model.ValidatorType
+	// itemType is the type of the items of an array or the keys of a map, empty if it is unknown
+	itemType    model.ValidatorType
+	diagnostics model.Diagnostics
+}
+
+// analyzeGroup analyses the conditions of the group and its inner groups.
+// If inConjunction is true the group was already checked together with its enclosing group.
+func (a *analyzer) analyzeGroup(group *model.AstValue, inConjunction bool) {
+	if group.Type != model.GROUP {
+		return
+	}
+
+	conjunction := isConjunction(group)
+	for _, v := range group.ConditionGroup {
+		if v.Type == model.CONDITION {
+			a.analyzeCondition(v)
+		} else {
+			a.analyzeGroup(v, conjunction)
+		}
+	}
+
+	if conjunction && !inConjunction {
+		a.analyzeConjunction(collectConjunction(group))
+	}
+}
+
+// isConjunction checks if all conditions and groups of the group are connected with AND.
+func isConjunction(group *model.AstValue) bool {
+	for i, v := range group.ConditionGroup {
+		if i < len(group.ConditionGroup)-1 && v.Operator == model.OR {
+			return false
+		}
+	}
+	return true
+}
+
+// collectConjunction collects the conditions of the group and its inner groups connected with AND.
+func collectConjunction(group *model.AstValue) []*model.AstValue {
+	conditions := []*model.AstValue{}
+	for _, v := range group.ConditionGroup {
+		if v.Type == model.CONDITION {
+			conditions = append(conditions, v)
+		} else if v.Type == model.GROUP && isConjunction(v) {
+			conditions = append(conditions, collectConjunction(v)...)
+		}
+	}
+	return conditions
+}
+
+// analyzeCondition checks if the condition applies to the validator type and if its value is valid.
+func (a *analyzer) analyzeCondition(condition *model.AstValue) {
+	if slices.Contains(inapplicableConditionTypes[a.validatorType], condition.ConditionType) {
+		a.add(model.DiagnosticInapplicable, condition, fmt.Sprintf("condition type %v does not apply to %v", condition.ConditionType, a.validatorType))
+		return
+	}
+
+	switch condition.ConditionType {
+	case model.EQUAL, model.NOT_EQUAL:
+		if _, ok := a.valueKey(condition.ConditionValue); !ok {
+			a.add(model.DiagnosticInvalidValue, condition, fmt.Sprintf("value %v is not convertible to %v", condition.ConditionValue, a.comparedType()))
+		}
+	case model.CONTAINS, model.NOT_CONTAINS:
+		if (a.validatorType == model.Array || a.validatorType == model.Map) && isScalarType(a.itemType) {
+			if _, ok := valueKeyOfType(condition.ConditionValue, a.itemType); !ok {
+				a.add(model.DiagnosticInvalidValue, condition, fmt.Sprintf("value %v is not convertible to %v", condition.ConditionValue, a.itemType))
+			}
+		}
+	case model.FROM, model.NOT_FROM:
+		valueType := a.validatorType
+		if valueType == model.Array || valueType == model.Map {
+			valueType = a.itemType
+		}
+		if condition.ParsedValue == nil && isScalarType(valueType) {
+			for _, value := range strings.Split(condition.ConditionValue, ",") {
+				if _, ok := valueKeyOfType(value, valueType); !ok {
+					a.add(model.DiagnosticInvalidValue, condition, fmt.Sprintf("value %v is not convertible to %v", value, valueType))
+					return
+				}
+			}
+		}
+	case model.REGX, model.FILE_NAME:
+		if _, err := regexp.Compile(condition.ConditionValue); err != nil {
+			a.add(model.DiagnosticInvalidValue, condition, fmt.Sprintf("invalid regular expression: %v", err))
+		}
+	case model.FILE_MIME, model.FILE_SNIFF:
+		for _, mediaType := range strings.Split(condition.ConditionValue, ",") {
+			if _, _, err := mime.ParseMediaType(mediaType); err != nil || !strings.Contains(mediaType, "/") {
+				a.add(model.DiagnosticInvalidValue, condition, fmt.Sprintf("invalid media type %v", mediaType))
+				return
+			}
+		}
+	}
+}
+
+// analyzeConjunction checks the conditions connected with AND for conditions which can never be fulfilled together
+// and for redundant conditions.
+func (a *analyzer) analyzeConjunction(conditions []*model.AstValue) {
+	conditions = slices.DeleteFunc(slices.Clone(conditions), func(condition *model.AstValue) bool {
+		return slices.Contains(inapplicableConditionTypes[a.validatorType], condition.ConditionType)
+	})
+
+	lower, upper := a.analyzeBounds(conditions)
+	equal := a.analyzeEqual(conditions, lower, upper)
+	a.analyzeSets(conditions, equal)
+}
+
+// analyzeBounds finds redundant `min` and `max` conditions and returns the strongest of them (nil if there is none).
+// If the strongest `min` is greater than the strongest `max` the `max` is unsatisfiable.
+func (a *analyzer) analyzeBounds(conditions []*model.AstValue) (*model.AstValue, *model.AstValue) {
+	var lower, upper *model.AstValue
+	for _, condition := range conditions {
+		switch condition.ConditionType {
+		case model.MIN_VALUE:
+			lower = a.strongerBound(lower, condition, func(x, y float64) bool { return x > y })
+		case model.MAX_VALUE:
+			upper = a.strongerBound(upper, condition, func(x, y float64) bool { return x < y })
+		}
+	}
+
+	if lower != nil && upper != nil && boundValue(lower) > boundValue(upper) {
+		a.add(model.DiagnosticUnsatisfiable, upper, fmt.Sprintf("can not be fulfilled together with %v", conditionString(lower)))
+	}
+	return lower, upper
+}
+
+// strongerBound returns the stronger of the two bounds and adds the other one as redundant.
+func (a *analyzer) strongerBound(current *model.AstValue, condition *model.AstValue, stronger func(x, y float64) bool) *model.AstValue {
+	if _, err := strconv.ParseFloat(condition.ConditionValue, 64); err != nil {
+		return current
+	} else if current == nil {
+		return condition
+	} else if stronger(boundValue(condition), boundValue(current)) {
+		a.add(model.DiagnosticRedundant, current, fmt.Sprintf("is always fulfilled because of %v", conditionString(condition)))
+		return condition
+	}
+	a.add(model.DiagnosticRedundant, condition, fmt.Sprintf("is always fulfilled because of %v", conditionString(current)))
+	return current
+}
+
+func boundValue(condition *model.AstValue) float64 {
+	value, _ := strconv.ParseFloat(condition.ConditionValue, 64)
+	return value
+}
+
+// analyzeEqual checks `equ` and `neq` conditions against each other and against the bounds.
+// It returns the first `equ` condition with a valid value (nil if there is none).
+func (a *analyzer) analyzeEqual(conditions []*model.AstValue, lower *model.AstValue, upper *model.AstValue) *model.AstValue {
+	var equal *model.AstValue
+	var equalKey string
+	notEqualKeys := map[string]*model.AstValue{}
+	for _, condition := range conditions {
+		if condition.ConditionType != model.EQUAL {
+			continue
+		}
+		key, ok := a.valueKey(condition.ConditionValue)
+		if !ok {
+			continue
+		} else if equal == nil {
+			equal, equalKey = condition, key
+		} else if key == equalKey {
+			a.add(model.DiagnosticRedundant, condition, fmt.Sprintf("is always fulfilled because of %v", conditionString(equal)))
+		} else {
+			a.add(model.DiagnosticUnsatisfiable, condition, fmt.Sprintf("can not be fulfilled together with %v", conditionString(equal)))
+		}
+	}
+
+	for _, condition := range conditions {
+		if condition.ConditionType != model.NOT_EQUAL {
+			continue
+		}
+		key, ok := a.valueKey(condition.ConditionValue)
+		if !ok {
+			continue
+		} else if equal != nil && key == equalKey {
+			a.add(model.DiagnosticUnsatisfiable, condition, fmt.Sprintf("can not be fulfilled together with %v", conditionString(equal)))
+		} else if equal != nil {
+			a.add(model.DiagnosticRedundant, condition, fmt.Sprintf("is always fulfilled because of %v", conditionString(equal)))
+		} else if notEqual, ok := notEqualKeys[key]; ok {
+			a.add(model.DiagnosticRedundant, condition, fmt.Sprintf("is the same as %v", conditionString(notEqual)))
+		} else {
+			notEqualKeys[key] = condition
+		}
+	}
+
+	// bounds only compare with `equ` if both use the number (int and float) or the length (array and map)
+	if equal == nil || a.validatorType == model.String || a.validatorType == model.Bool || a.validatorType == model.Struct {
+		return equal
+	}
+	value, err := strconv.ParseFloat(equalKey, 64)
+	if err != nil {
+		return equal
+	}
+	for _, bound := range []*model.AstValue{lower, upper} {
+		if bound == nil {
+			continue
+		} else if (bound == lower && value < boundValue(bound)) || (bound == upper && value > boundValue(bound)) {
+			a.add(model.DiagnosticUnsatisfiable, bound, fmt.Sprintf("can not be fulfilled together with %v", conditionString(equal)))
+		} else {
+			a.add(model.DiagnosticRedundant, bound, fmt.Sprintf("is always fulfilled because of %v", conditionString(equal)))
+		}
+	}
+	return equal
+}
+
+// analyzeSets checks the values allowed by `frm`, `nfr`, `enum` and `equ` conditions of single values.
+// The values allowed by all `frm`, `enum` and `equ` conditions are collected first and the values of the
+// `nfr` conditions are removed afterwards, so the result does not depend on the order of the conditions.
+// If no value is allowed by all of them the condition removing the last value is unsatisfiable.
+func (a *analyzer) analyzeSets(conditions []*model.AstValue, equal *model.AstValue) {
+	if !a.isScalar() {
+		return
+	}
+
+	var allowed model.ValueSet
+	if equal != nil {
+		key, _ := a.valueKey(equal.ConditionValue)
+		allowed = model.NewValueSet(key)
+	}
+	notFrom := []*model.AstValue{}
+	for _, condition := range conditions {
+		if condition.ConditionType == model.NOT_FROM {
+			notFrom = append(notFrom, condition)
+			continue
+		} else if condition.ConditionType != model.FROM && condition.ConditionType != model.ENUM {
+			continue
+		}
+		values, ok := a.valueSet(condition)
+		if !ok {
+			continue
+		} else if allowed == nil {
+			allowed = values
+			continue
+		}
+		allowed = a.restrictSet(condition, allowed, func(value string) bool { return values.Contains(value) })
+	}
+	if allowed == nil {
+		return
+	}
+
+	for _, condition := range notFrom {
+		values, ok := a.valueSet(condition)
+		if !ok {
+			continue
+		}
+		allowed = a.restrictSet(condition, allowed, func(value string) bool { return !values.Contains(value) })
+	}
+}
+
+// restrictSet returns the allowed values which are kept by the condition. The condition is unsatisfiable
+// if no value is kept and redundant if all values are kept.
+func (a *analyzer) restrictSet(condition *model.AstValue, allowed model.ValueSet, keep func(value string) bool) model.ValueSet {
+	remaining := model.ValueSet{}
+	for value := range allowed {
+		if keep(value) {
+			remaining[value] = struct{}{}
+		}
+	}
+
+	if len(remaining) == 0 {
+		a.add(model.DiagnosticUnsatisfiable, condition, "no value is allowed by all conditions")
+	} else if len(remaining) == len(allowed) {
+		a.add(model.DiagnosticRedundant, condition, "does not restrict the values allowed by the other conditions")
+	}
+	return remaining
+}
+
+// valueSet returns the values of a `frm`, `nfr` or `enum` condition as keys of a model.ValueSet.
+func (a *analyzer) valueSet(condition *model.AstValue) (model.ValueSet, bool) {
+	if valueSet, ok := condition.ParsedValue.(model.ValueSet); ok {
+		return valueSet, true
+	} else if condition.ConditionType == model.ENUM {
+		return nil, false
+	}
+
+	valueSet := model.ValueSet{}
+	for _, value := range strings.Split(condition.ConditionValue, ",") {
+		key, ok := a.valueKey(value)
+		if !ok {
+			return nil, false
+		}
+		valueSet[key] = struct{}{}
+	}
+	return valueSet, true
+}
+
+// valueKey converts the condition value to the type compared by `equ` and returns its key in a model.ValueSet
+// (see helper.AnyToValueSetKey), so values like `1.0` and `1` of a float are the same.
+func (a *analyzer) valueKey(value string) (string, bool) {
+	return valueKeyOfType(value, a.comparedType())
+}
+
+// valueKeyOfType converts the condition value to the validator type and returns its key in a model.ValueSet.
+func valueKeyOfType(value string, validatorType model.ValidatorType) (string, bool) {
+	converted, err := helper.AnyToType(value, validatorType.ToReflectType())
+	if err != nil {
+		return "", false
+	}
+	key, err := helper.AnyToValueSetKey(converted)
+	if err != nil {
+		return "", false
+	}
+	return key, true
+}
+
+// comparedType is the type compared by `equ` and `neq`, arrays and maps compare their length.
+func (a *analyzer) comparedType() model.ValidatorType {
+	switch a.validatorType {
+	case model.Array, model.Map:
+		return model.Int
+	case model.Struct:
+		return model.String
+	default:
+		return a.validatorType
+	}
+}
+
+// isScalar checks if the validator type has single values (string, int, float and bool).
+func (a *analyzer) isScalar() bool {
+	return isScalarType(a.validatorType)
+}
+
+func isScalarType(validatorType model.ValidatorType) bool {
+	return validatorType == model.String || validatorType == model.Int || validatorType == model.Float || validatorType == model.Bool
+}
+
+func (a *analyzer) add(kind model.DiagnosticKind, condition *model.AstValue, message string) {
+	a.diagnostics = append(a.diagnostics, model.Diagnostic{
+		Kind:      kind,
+		Condition: conditionString(condition),
+		Message:   message,
+		Start:     condition.Start,
+		End:       condition.End,
+	})
+}
+
+// conditionString returns the condition without its operator (eg. `max'5'`).
+func conditionString(condition *model.AstValue) string {
+	withoutOperator := *condition
+	withoutOperator.Operator = ""
+	return withoutOperator.AstConditionToString()
+}
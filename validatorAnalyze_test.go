@@ -0,0 +1,170 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/siherrmann/validator/model"
+	"github.com/siherrmann/validator/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeRequirement(t *testing.T) {
+	type diagnostic struct {
+		kind      model.DiagnosticKind
+		condition string
+	}
+	tests := []struct {
+		name          string
+		requirement   string
+		validatorType model.ValidatorType
+		expected      []diagnostic
+	}{
+		{
+			name:          "Valid requirement",
+			requirement:   "min3 max10 rex^[a-z]+$",
+			validatorType: model.String,
+			expected:      []diagnostic{},
+		},
+		{
+			name:          "Unsatisfiable min and max",
+			requirement:   "min10 && max5",
+			validatorType: model.Int,
+			expected:      []diagnostic{{model.DiagnosticUnsatisfiable, "max'5'"}},
+		},
+		{
+			name:          "Unsatisfiable equ and neq",
+			requirement:   "equ3 && neq3.0",
+			validatorType: model.Float,
+			expected:      []diagnostic{{model.DiagnosticUnsatisfiable, "neq'3.0'"}},
+		},
+		{
+			name:          "Unsatisfiable frm and nfr",
+			requirement:   "frma,b && nfra,b",
+			validatorType: model.String,
+			expected:      []diagnostic{{model.DiagnosticUnsatisfiable, "nfr'a,b'"}},
+		},
+		{
+			name:          "Unsatisfiable nfr before frm",
+			requirement:   "nfra,b && frma,b",
+			validatorType: model.String,
+			expected:      []diagnostic{{model.DiagnosticUnsatisfiable, "nfr'a,b'"}},
+		},
+		{
+			name:          "Redundant nfr before frm",
+			requirement:   "nfrc && frma,b",
+			validatorType: model.String,
+			expected:      []diagnostic{{model.DiagnosticRedundant, "nfr'c'"}},
+		},
+		{
+			name:          "Unsatisfiable equ and frm list",
+			requirement:   "equc && frm['a', 'b']",
+			validatorType: model.String,
+			expected:      []diagnostic{{model.DiagnosticUnsatisfiable, "frm['a', 'b']"}},
+		},
+		{
+			name:          "Unsatisfiable equ and min of array length",
+			requirement:   "equ2 min3",
+			validatorType: model.Array,
+			expected:      []diagnostic{{model.DiagnosticUnsatisfiable, "min'3'"}},
+		},
+		{
+			name:          "Unsatisfiable conditions in nested groups",
+			requirement:   "min10 && (max20 && (max5))",
+			validatorType: model.Int,
+			expected: []diagnostic{
+				{model.DiagnosticRedundant, "max'20'"},
+				{model.DiagnosticUnsatisfiable, "max'5'"},
+			},
+		},
+		{
+			name:          "Redundant conditions",
+			requirement:   "min3 && min5 && equ4 && neq5",
+			validatorType: model.Int,
+			expected: []diagnostic{
+				{model.DiagnosticRedundant, "min'3'"},
+				{model.DiagnosticRedundant, "neq'5'"},
+				{model.DiagnosticUnsatisfiable, "min'5'"},
+			},
+		},
+		{
+			name:          "No conflicts between alternatives",
+			requirement:   "max0 || ((min10 && max30) || equTest)",
+			validatorType: model.String,
+			expected:      []diagnostic{},
+		},
+		{
+			name:          "Inapplicable conditions",
+			requirement:   "rex^a$ || fun:check",
+			validatorType: model.Struct,
+			expected:      []diagnostic{{model.DiagnosticInapplicable, "rex'^a$'"}},
+		},
+		{
+			name:          "Inapplicable file condition in alternative",
+			requirement:   "min1 || fmx1MB",
+			validatorType: model.String,
+			expected:      []diagnostic{{model.DiagnosticInapplicable, "fmx'1MB'"}},
+		},
+		{
+			name:          "Invalid condition values",
+			requirement:   "equabc || frm1,x || rex[a-",
+			validatorType: model.Int,
+			expected: []diagnostic{
+				{model.DiagnosticInvalidValue, "equ'abc'"},
+				{model.DiagnosticInvalidValue, "frm'1,x'"},
+				{model.DiagnosticInvalidValue, "rex'[a-'"},
+			},
+		},
+		{
+			name:          "Invalid media type",
+			requirement:   "mimimage",
+			validatorType: model.Array,
+			expected:      []diagnostic{{model.DiagnosticInvalidValue, "mim'image'"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rootNode, err := parser.NewParser().ParseValidation(test.requirement)
+			require.NoError(t, err, "Expected no error parsing requirement")
+
+			diagnostics := AnalyzeRequirement(rootNode, test.validatorType)
+			actual := []diagnostic{}
+			for _, d := range diagnostics {
+				assert.NotEmpty(t, d.Message, "Expected diagnostic message")
+				assert.True(t, strings.HasPrefix(d.Condition, test.requirement[d.Start:d.Start+3]), "Expected position of condition %v", d.Condition)
+				actual = append(actual, diagnostic{d.Kind, d.Condition})
+			}
+			assert.Equal(t, test.expected, actual, "Expected diagnostics to match")
+		})
+	}
+}
+
+func TestAnalyzeStruct(t *testing.T) {
+	v := NewValidator()
+	require.NoError(t, v.DefineAlias("short", "max3"), "Expected no error defining alias")
+
+	type address struct {
+		City string `json:"city" vld:"min5 @short"`
+	}
+	type user struct {
+		Name    string  `json:"name" vld:"min3 max20"`
+		Age     int     `json:"age" vld:"min18 && max5"`
+		Address address `json:"address" vld:"-"`
+	}
+
+	diagnostics, err := v.AnalyzeStruct(&user{})
+	require.NoError(t, err, "Expected no error analysing struct")
+	require.Len(t, diagnostics, 2, "Expected diagnostics for age and address.city, got: %v", diagnostics)
+	assert.Equal(t, "age", diagnostics[0].Key, "Expected key of field")
+	assert.Equal(t, "address.city", diagnostics[1].Key, "Expected key of inner field")
+	assert.True(t, diagnostics.HasErrors(), "Expected errors in diagnostics")
+	assert.Equal(t, "field age: unsatisfiable condition max'5': can not be fulfilled together with min'18'", diagnostics[0].String(), "Expected diagnostic string to match")
+
+	type invalid struct {
+		Name string `json:"name" vld:"mux3"`
+	}
+	_, err = v.AnalyzeStruct(&invalid{})
+	assert.Error(t, err, "Expected error for invalid requirement")
+}
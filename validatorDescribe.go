@@ -0,0 +1,350 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/siherrmann/validator/model"
+)
+
+// DescribeRequirement returns a natural-language description of a parsed requirement for a field
+// of the validator type, eg. `must be empty, or 10–30 characters long, or exactly 'Test'`
+// for `max0 || ((min10 && max30) || equTest)` on a string.
+// Conditions connected with AND are joined with `and`, `min` and `max` are described together as range
+// and groups with OR inside of a group with AND are described with `either ... or ...`.
+// Custom condition types are described with their Description. It returns an empty string
+// for requirements without conditions (eg. `-`).
+func (r *Validator) DescribeRequirement(rootNode model.RootNode, validatorType model.ValidatorType) string {
+	if rootNode.RootValue == nil {
+		return ""
+	}
+	d := &describer{validatorType: validatorType, conditionTypes: r.ConditionTypes}
+	description := d.describeGroup(rootNode.RootValue, false)
+	if len(description) == 0 {
+		return ""
+	}
+	return "must " + description
+}
+
+// DescribeValidation parses the requirement of the validation with the settings of the Validator
+// (eg. aliases and custom condition types) and describes it (see DescribeRequirement).
+// It returns an error if the requirement can not be parsed.
+func (r *Validator) DescribeValidation(validation *model.Validation) (string, error) {
+	rootNode, err := r.parseValidation(validation)
+	if err != nil {
+		return "", fmt.Errorf("error parsing requirement of %v: %w", validation.Key, err)
+	}
+	return r.DescribeRequirement(rootNode, validation.Type), nil
+}
+
+// DescribeStruct describes the requirements of all fields of a struct by the given tagType (see DescribeValidation),
+// including the fields of inner structs (with keys like `address.city`). It can be used for hints in a UI.
+// Fields without conditions are not included.
+func (r *Validator) DescribeStruct(v any, tagType ...string) (map[string]string, error) {
+	tagTypeSet := model.VLD
+	if len(tagType) > 0 {
+		tagTypeSet = tagType[0]
+	}
+
+	validations, err := r.GetValidationsFromStruct(v, tagTypeSet)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validations from struct: %v", err)
+	}
+
+	descriptions := map[string]string{}
+	err = r.describeValidations(descriptions, validations, "")
+	if err != nil {
+		return nil, err
+	}
+	return descriptions, nil
+}
+
+func (r *Validator) describeValidations(descriptions map[string]string, validations []model.Validation, keyPrefix string) error {
+	for _, validation := range validations {
+		validation.Key = keyPrefix + validation.Key
+		description, err := r.DescribeValidation(&validation)
+		if err != nil {
+			return err
+		}
+		if len(description) > 0 {
+			descriptions[validation.Key] = description
+		}
+
+		err = r.describeValidations(descriptions, validation.InnerValidation, validation.Key+".")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// describeConditionGroupError returns the description of a group with OR for the error of the group,
+// the validator type is taken from the input.
+func (r *Validator) describeConditionGroupError(input any, astValue *model.AstValue) string {
+	validatorType := model.Struct
+	if input != nil {
+		validatorType = model.ReflectKindToValidatorType(reflect.TypeOf(input).Kind())
+	}
+	return r.DescribeRequirement(model.RootNode{RootValue: astValue}, validatorType)
+}
+
+type describer struct {
+	validatorType  model.ValidatorType
+	conditionTypes map[model.ConditionType]CustomConditionType
+}
+
+// describeGroup describes the group as alternatives connected with OR (see describeAlternatives).
+// If nested is true the alternatives are described as `either ... or ...`.
+func (d *describer) describeGroup(group *model.AstValue, nested bool) string {
+	switch group.Type {
+	case model.EMPTY:
+		return ""
+	case model.CONDITION:
+		return d.describeCondition(group)
+	}
+
+	phrases := d.describeAlternatives(group)
+	if len(phrases) > 1 && nested {
+		return "either " + joinPhrases(phrases, " or ")
+	}
+	return joinPhrases(phrases, ", or ")
+}
+
+// describeAlternatives describes the alternatives connected with OR of the group, each alternative
+// are the conditions and groups connected with AND between them (see describeConjunction).
+// The alternatives of an inner group which is an alternative itself are added to the alternatives of the group.
+func (d *describer) describeAlternatives(group *model.AstValue) []string {
+	alternatives := [][]*model.AstValue{}
+	conjunction := []*model.AstValue{}
+	for i, v := range group.ConditionGroup {
+		conjunction = append(conjunction, v)
+		if i < len(group.ConditionGroup)-1 && v.Operator == model.OR {
+			alternatives = append(alternatives, conjunction)
+			conjunction = []*model.AstValue{}
+		}
+	}
+	alternatives = append(alternatives, conjunction)
+
+	phrases := []string{}
+	for _, alternative := range alternatives {
+		if len(alternative) == 1 && alternative[0].Type == model.GROUP {
+			phrases = append(phrases, d.describeAlternatives(alternative[0])...)
+		} else if phrase := d.describeConjunction(alternative); len(phrase) > 0 {
+			phrases = append(phrases, phrase)
+		}
+	}
+	return phrases
+}
+
+// describeConjunction describes conditions and groups connected with AND.
+// The first `min` and `max` are described together as range (eg. `10–30 characters long`).
+func (d *describer) describeConjunction(items []*model.AstValue) string {
+	var minCondition, maxCondition *model.AstValue
+	for _, v := range items {
+		if v.Type == model.CONDITION && v.ConditionType == model.MIN_VALUE && minCondition == nil {
+			minCondition = v
+		} else if v.Type == model.CONDITION && v.ConditionType == model.MAX_VALUE && maxCondition == nil {
+			maxCondition = v
+		}
+	}
+
+	phrases := []string{}
+	rangeDescribed := false
+	for _, v := range items {
+		var phrase string
+		switch {
+		case minCondition != nil && maxCondition != nil && (v == minCondition || v == maxCondition):
+			if !rangeDescribed {
+				phrase = d.describeRange(minCondition.ConditionValue, maxCondition.ConditionValue)
+				rangeDescribed = true
+			}
+		case v.Type == model.GROUP:
+			phrase = d.describeGroup(v, true)
+		default:
+			phrase = d.describeCondition(v)
+		}
+		if len(phrase) > 0 {
+			phrases = append(phrases, phrase)
+		}
+	}
+	return joinPhrases(phrases, " and ")
+}
+
+// describeRange describes a `min` and a `max` condition together.
+func (d *describer) describeRange(minValue string, maxValue string) string {
+	if minValue == maxValue {
+		return d.describeSize("exactly", minValue)
+	}
+	switch d.validatorType {
+	case model.String:
+		return fmt.Sprintf("be %v–%v characters long", minValue, maxValue)
+	case model.Array:
+		return fmt.Sprintf("have %v–%v items", minValue, maxValue)
+	case model.Map:
+		return fmt.Sprintf("have %v–%v entries", minValue, maxValue)
+	default:
+		return fmt.Sprintf("be between %v and %v", minValue, maxValue)
+	}
+}
+
+// describeSize describes the length of strings, arrays and maps or the value of other types
+// compared by `min`, `max` or `equ` (eg. `at least 3 characters long`).
+func (d *describer) describeSize(comparison string, value string) string {
+	switch d.validatorType {
+	case model.String:
+		return fmt.Sprintf("be %v %v %v long", comparison, value, plural(value, "character", "characters"))
+	case model.Array:
+		return fmt.Sprintf("have %v %v %v", comparison, value, plural(value, "item", "items"))
+	case model.Map:
+		return fmt.Sprintf("have %v %v %v", comparison, value, plural(value, "entry", "entries"))
+	default:
+		return fmt.Sprintf("be %v %v", comparison, value)
+	}
+}
+
+// describeCondition describes a single condition (eg. `be at least 3 characters long` for `min3` on a string).
+func (d *describer) describeCondition(condition *model.AstValue) string {
+	value := condition.ConditionValue
+	isCollection := d.validatorType == model.Array || d.validatorType == model.Map
+
+	switch condition.ConditionType {
+	case model.NONE:
+		return ""
+	case model.MIN_VALUE:
+		return d.describeSize("at least", value)
+	case model.MAX_VALUE:
+		if value == "0" && (d.validatorType == model.String || isCollection) {
+			return "be empty"
+		}
+		return d.describeSize("at most", value)
+	case model.EQUAL:
+		if isCollection {
+			return d.describeSize("exactly", value)
+		}
+		return fmt.Sprintf("be exactly %v", d.quote(value))
+	case model.NOT_EQUAL:
+		if isCollection {
+			return "not " + d.describeSize("exactly", value)
+		}
+		return fmt.Sprintf("not be %v", d.quote(value))
+	case model.CONTAINS:
+		if d.validatorType == model.Map {
+			return fmt.Sprintf("contain the key %v", d.quote(value))
+		}
+		return fmt.Sprintf("contain %v", d.quote(value))
+	case model.NOT_CONTAINS:
+		if d.validatorType == model.Map {
+			return fmt.Sprintf("not contain the key %v", d.quote(value))
+		}
+		return fmt.Sprintf("not contain %v", d.quote(value))
+	case model.FROM, model.ENUM:
+		values := d.listValues(condition)
+		switch {
+		case len(values) == 0:
+			return "be a value of the enum"
+		case d.validatorType == model.Array:
+			return fmt.Sprintf("only contain items from %v", strings.Join(values, ", "))
+		case d.validatorType == model.Map:
+			return fmt.Sprintf("only contain keys from %v", strings.Join(values, ", "))
+		default:
+			return fmt.Sprintf("be one of %v", strings.Join(values, ", "))
+		}
+	case model.NOT_FROM:
+		values := d.listValues(condition)
+		switch d.validatorType {
+		case model.Array:
+			return fmt.Sprintf("not contain any of %v", strings.Join(values, ", "))
+		case model.Map:
+			return fmt.Sprintf("not contain any of the keys %v", strings.Join(values, ", "))
+		default:
+			return fmt.Sprintf("not be one of %v", strings.Join(values, ", "))
+		}
+	case model.REGX:
+		return fmt.Sprintf("match the pattern '%v'", value)
+	case model.FILE_MIN_SIZE:
+		return fmt.Sprintf("have a file size of at least %v", value)
+	case model.FILE_MAX_SIZE:
+		return fmt.Sprintf("have a file size of at most %v", value)
+	case model.FILE_MIME:
+		return fmt.Sprintf("have one of the content types %v", strings.Join(d.splitValues(value), ", "))
+	case model.FILE_SNIFF:
+		return fmt.Sprintf("have content of one of the media types %v", strings.Join(d.splitValues(value), ", "))
+	case model.FILE_NAME:
+		return fmt.Sprintf("have a file name matching the pattern '%v'", value)
+	case model.FUNC:
+		if condition.ConditionArgs != nil {
+			return fmt.Sprintf("pass the check '%v(%v)'", value, model.FormatLiterals(condition.ConditionArgs))
+		}
+		return fmt.Sprintf("pass the check '%v'", value)
+	default:
+		if conditionType, ok := d.conditionTypes[condition.ConditionType]; ok && len(conditionType.Description) > 0 {
+			return fmt.Sprintf("be a valid %v '%v'", conditionType.Description, value)
+		}
+		return fmt.Sprintf("fulfil %v '%v'", condition.ConditionType, value)
+	}
+}
+
+// listValues returns the quoted values of `frm`, `nfr` and `enum` conditions
+// from a list literal, a value list, the enum values or the comma separated condition value.
+func (d *describer) listValues(condition *model.AstValue) []string {
+	values := []string{}
+	switch {
+	case condition.ConditionArgs != nil:
+		for _, arg := range condition.ConditionArgs {
+			values = append(values, d.quote(fmt.Sprint(arg)))
+		}
+	case condition.ParsedValue != nil:
+		if valueSet, ok := condition.ParsedValue.(model.ValueSet); ok {
+			for _, value := range valueSet.Values() {
+				values = append(values, d.quote(value))
+			}
+		}
+	case len(condition.ConditionValue) > 0:
+		values = d.splitValues(condition.ConditionValue)
+	}
+	return values
+}
+
+// splitValues returns the quoted values of a comma separated condition value.
+func (d *describer) splitValues(value string) []string {
+	values := []string{}
+	for _, v := range strings.Split(value, ",") {
+		values = append(values, d.quote(v))
+	}
+	return values
+}
+
+// quote returns the value in `'`, numbers and booleans of int, float and bool fields are not quoted.
+func (d *describer) quote(value string) string {
+	switch d.validatorType {
+	case model.Int, model.Float, model.Bool:
+		return value
+	default:
+		return "'" + value + "'"
+	}
+}
+
+// joinPhrases joins the phrases with the separator and leaves out a leading `be`
+// if the phrase before also starts with it (eg. `be empty, or exactly 'Test'`).
+func joinPhrases(phrases []string, separator string) string {
+	joined := strings.Builder{}
+	for i, phrase := range phrases {
+		if i > 0 {
+			joined.WriteString(separator)
+			if strings.HasPrefix(phrase, "be ") && strings.HasPrefix(phrases[i-1], "be ") {
+				phrase = strings.TrimPrefix(phrase, "be ")
+			}
+		}
+		joined.WriteString(phrase)
+	}
+	return joined.String()
+}
+
+// plural returns the singular for the count `1` and the plural for all other counts.
+func plural(count string, singular string, pluralForm string) string {
+	if count == "1" {
+		return singular
+	}
+	return pluralForm
+}
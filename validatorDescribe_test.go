@@ -0,0 +1,130 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/siherrmann/validator/model"
+	"github.com/siherrmann/validator/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribeRequirement(t *testing.T) {
+	tests := []struct {
+		name          string
+		requirement   string
+		validatorType model.ValidatorType
+		expected      string
+	}{
+		{
+			name:          "String with or groups",
+			requirement:   "max0 || ((min10 && max30) || equTest)",
+			validatorType: model.String,
+			expected:      "must be empty, or 10–30 characters long, or exactly 'Test'",
+		},
+		{
+			name:          "String with and conditions",
+			requirement:   "min1 && neqadmin && rex^[a-z]+$",
+			validatorType: model.String,
+			expected:      "must be at least 1 character long and not be 'admin' and match the pattern '^[a-z]+$'",
+		},
+		{
+			name:          "String with nested or group",
+			requirement:   "min3 && (con@ || equadmin)",
+			validatorType: model.String,
+			expected:      "must be at least 3 characters long and either contain '@' or be exactly 'admin'",
+		},
+		{
+			name:          "String with list",
+			requirement:   "frm['a', 'b c'] || nfr['@']",
+			validatorType: model.String,
+			expected:      "must be one of 'a', 'b c', or not be one of '@'",
+		},
+		{
+			name:          "Int range",
+			requirement:   "min1 max10 neq5",
+			validatorType: model.Int,
+			expected:      "must be between 1 and 10 and not be 5",
+		},
+		{
+			name:          "Float with same min and max",
+			requirement:   "max2.5 && min2.5",
+			validatorType: model.Float,
+			expected:      "must be exactly 2.5",
+		},
+		{
+			name:          "Array",
+			requirement:   "max0 || (min1 && max3 && frma,b)",
+			validatorType: model.Array,
+			expected:      "must be empty, or have 1–3 items and only contain items from 'a', 'b'",
+		},
+		{
+			name:          "Map",
+			requirement:   "equ1 && conid",
+			validatorType: model.Map,
+			expected:      "must have exactly 1 entry and contain the key 'id'",
+		},
+		{
+			name:          "Files and function",
+			requirement:   "fmx5MB && mimapplication/pdf,image/png && fun:isSigned('sha256')",
+			validatorType: model.Struct,
+			expected:      "must have a file size of at most 5MB and have one of the content types 'application/pdf', 'image/png' and pass the check 'isSigned(\"sha256\")'",
+		},
+		{
+			name:          "Empty requirement",
+			requirement:   "-",
+			validatorType: model.String,
+			expected:      "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rootNode, err := parser.NewParser().ParseValidation(test.requirement)
+			require.NoError(t, err, "Expected no error parsing requirement")
+			assert.Equal(t, test.expected, DescribeRequirement(rootNode, test.validatorType), "Expected description to match")
+		})
+	}
+}
+
+func TestDescribeStruct(t *testing.T) {
+	type Address struct {
+		City string `json:"city" vld:"min1"`
+	}
+	type User struct {
+		Name     string  `json:"name" vld:"max0 || ((min10 && max30) || equTest)"`
+		Currency string  `json:"currency" vld:"currencyEUR,USD"`
+		Age      int     `json:"age" vld:"-"`
+		Address  Address `json:"address" vld:"-"`
+	}
+
+	v := NewValidator()
+	err := v.AddConditionType("currency", CustomConditionType{
+		Validate:    func(input any, astValue *model.AstValue) error { return nil },
+		Description: "currency code from the list",
+	})
+	require.NoError(t, err, "Expected no error adding condition type")
+
+	descriptions, err := v.DescribeStruct(&User{})
+	require.NoError(t, err, "Expected no error describing struct")
+	assert.Equal(t, map[string]string{
+		"name":         "must be empty, or 10–30 characters long, or exactly 'Test'",
+		"currency":     "must be a valid currency code from the list 'EUR,USD'",
+		"address.city": "must be at least 1 character long",
+	}, descriptions, "Expected descriptions to match")
+}
+
+func TestDescribeConditionGroupError(t *testing.T) {
+	validation := model.Validation{Key: "name", Type: model.String, Requirement: "max0 || min3"}
+	_, err := ValidateWithValidation(map[string]any{"name": "ab"}, []model.Validation{validation})
+	require.Error(t, err, "Expected error for invalid value")
+
+	var groupErr *model.ConditionGroupError
+	require.True(t, errors.As(err, &groupErr), "Expected condition group error")
+	assert.Equal(t, "must be empty, or at least 3 characters long", groupErr.Message(), "Expected description as message")
+
+	problemErrors := model.ProblemErrorsFromError(err)
+	require.Len(t, problemErrors, 1, "Expected one problem error")
+	assert.Equal(t, "must be empty, or at least 3 characters long", problemErrors[0].Message, "Expected description as problem message")
+}
@@ -0,0 +1,109 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/siherrmann/validator/helper"
+	"github.com/siherrmann/validator/model"
+)
+
+// ExplainValue validates the input against the requirement of the validation and returns a trace of the evaluation
+// (see model.Trace). The trace mirrors the AST of the requirement with the outcome and error of every condition and group
+// and the conditions and groups skipped after a failed condition connected with AND.
+// A failed validation is part of the trace, it returns an error if the requirement can not be parsed
+// or the validation itself failed (eg. an unknown validation function or a canceled context).
+func (r *Validator) ExplainValue(input any, validation *model.Validation) (*model.Trace, error) {
+	return r.ExplainValueContext(context.Background(), input, validation)
+}
+
+// ExplainValueContext does the same as ExplainValue, but passes the context
+// to the context aware validation functions (see AddValidationFuncCtx).
+func (r *Validator) ExplainValueContext(ctx context.Context, input any, validation *model.Validation) (*model.Trace, error) {
+	rootNode, err := r.parseValidation(validation)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing requirement of %v: %w", validation.Key, err)
+	}
+
+	trace := model.NewRootTrace(validation.Key, rootNode, input)
+	err = r.runConditionGroup(ctx, input, rootNode.RootValue, trace)
+	trace.SetOutcome(err)
+
+	switch err.(type) {
+	case nil, *model.ConditionError, *model.ConditionGroupError:
+		return trace, nil
+	default:
+		return trace, err
+	}
+}
+
+// ExplainStruct validates the fields of a struct by the given tagType like Validate and returns a trace
+// of every field with a requirement (see ExplainWithValidation).
+func (r *Validator) ExplainStruct(v any, tagType ...string) ([]*model.Trace, error) {
+	tagTypeSet := model.VLD
+	if len(tagType) > 0 {
+		tagTypeSet = tagType[0]
+	}
+
+	jsonMap := map[string]any{}
+	err := helper.UnmapStructToJsonMap(v, &jsonMap)
+	if err != nil {
+		return nil, fmt.Errorf("error unmapping struct to json map: %v", err)
+	}
+
+	validations, err := r.GetValidationsFromStruct(v, tagTypeSet)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validations from struct: %v", err)
+	}
+
+	return r.ExplainWithValidation(jsonMap, validations)
+}
+
+// ExplainWithValidation validates the JsonMap by the validations like ValidateWithValidation and returns a trace
+// of every field with a requirement (see ExplainValue), including the fields of inner structs
+// (with keys like `address.city`) and arrays of structs (with keys like `items[0].name`).
+// It does not stop at the first invalid field, so it can be used in tests to debug tags.
+// Fields missing in the JsonMap are not traced.
+func (r *Validator) ExplainWithValidation(jsonInput map[string]any, validations []model.Validation) ([]*model.Trace, error) {
+	return r.explainValidations([]*model.Trace{}, jsonInput, validations, "")
+}
+
+func (r *Validator) explainValidations(traces []*model.Trace, jsonInput map[string]any, validations []model.Validation, keyPrefix string) ([]*model.Trace, error) {
+	for _, validation := range validations {
+		jsonValue, ok := jsonInput[validation.Key]
+		if !ok {
+			continue
+		}
+		validation.Key = keyPrefix + validation.Key
+
+		var err error
+		jsonValueMap, isMap := jsonValue.(map[string]any)
+		jsonArray, isArray := jsonValue.([]any)
+		switch {
+		case validation.Type == model.Struct && isMap:
+			traces, err = r.explainValidations(traces, jsonValueMap, validation.InnerValidation, validation.Key+".")
+		case validation.Type == model.Array && isArray && len(validation.InnerValidation) > 0:
+			for index, jsonValueInner := range jsonArray {
+				jsonValueInnerMap, err := helper.GetValidMap(jsonValueInner)
+				if err != nil {
+					return nil, fmt.Errorf("error explaining %v[%d]: %v", validation.Key, index, err)
+				}
+				traces, err = r.explainValidations(traces, jsonValueInnerMap, validation.InnerValidation, fmt.Sprintf("%v[%d].", validation.Key, index))
+				if err != nil {
+					return nil, err
+				}
+			}
+		case strings.TrimSpace(validation.Requirement) != string(model.NONE):
+			var trace *model.Trace
+			trace, err = r.ExplainValue(jsonValue, &validation)
+			if trace != nil {
+				traces = append(traces, trace)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return traces, nil
+}
@@ -0,0 +1,180 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/siherrmann/validator/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainValue(t *testing.T) {
+	type condition struct {
+		condition string
+		outcome   model.TraceOutcome
+	}
+	tests := []struct {
+		name            string
+		requirement     string
+		validatorType   model.ValidatorType
+		input           any
+		expectedOutcome model.TraceOutcome
+		expected        []condition
+	}{
+		{
+			name:            "Passed conditions",
+			requirement:     "min3 max10",
+			validatorType:   model.String,
+			input:           "valid",
+			expectedOutcome: model.TracePassed,
+			expected:        []condition{{"min3", model.TracePassed}, {"max10", model.TracePassed}},
+		},
+		{
+			name:            "Skipped conditions after failed condition with and",
+			requirement:     "min3 && (max10 || equa) && conx",
+			validatorType:   model.String,
+			input:           "a",
+			expectedOutcome: model.TraceFailed,
+			expected:        []condition{{"min3", model.TraceFailed}, {"max10", model.TraceSkipped}, {"equa", model.TraceSkipped}, {"conx", model.TraceSkipped}},
+		},
+		{
+			name:            "Failed group with or",
+			requirement:     "max0 || ((min10 && max30) || equTest)",
+			validatorType:   model.String,
+			input:           "short",
+			expectedOutcome: model.TraceFailed,
+			expected:        []condition{{"max0", model.TraceFailed}, {"min10", model.TraceFailed}, {"max30", model.TraceSkipped}, {"equTest", model.TraceFailed}},
+		},
+		{
+			name:            "Passed group with or",
+			requirement:     "max0 || min3",
+			validatorType:   model.Int,
+			input:           5,
+			expectedOutcome: model.TracePassed,
+			expected:        []condition{{"max0", model.TraceFailed}, {"min3", model.TracePassed}},
+		},
+		{
+			name:            "Empty requirement",
+			requirement:     "-",
+			validatorType:   model.String,
+			input:           "",
+			expectedOutcome: model.TracePassed,
+			expected:        []condition{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			validation := &model.Validation{Key: "field", Type: test.validatorType, Requirement: test.requirement}
+			trace, err := ExplainValue(test.input, validation)
+			require.NoError(t, err, "Expected no error explaining value")
+			assert.Equal(t, test.expectedOutcome, trace.Outcome, "Expected outcome to match")
+			assert.Equal(t, "field", trace.Key, "Expected key of field")
+			assert.Equal(t, test.input, trace.Input, "Expected input in trace")
+
+			conditions := []condition{}
+			for _, conditionTrace := range trace.Conditions() {
+				conditions = append(conditions, condition{conditionTrace.Condition, conditionTrace.Outcome})
+			}
+			assert.Equal(t, test.expected, conditions, "Expected outcomes of conditions to match")
+
+			// the trace has the same result as the validation
+			validationErr := NewValidator().ValidateValueWithParser(test.input, validation)
+			assert.Equal(t, validationErr == nil, trace.Outcome == model.TracePassed, "Expected same result as validation")
+		})
+	}
+
+	t.Run("Invalid requirement", func(t *testing.T) {
+		_, err := ExplainValue("a", &model.Validation{Key: "field", Type: model.String, Requirement: "mux3"})
+		assert.Error(t, err, "Expected error for invalid requirement")
+	})
+
+	t.Run("Unknown validation function", func(t *testing.T) {
+		trace, err := ExplainValue("a", &model.Validation{Key: "field", Type: model.String, Requirement: "fununknown || min1"})
+		assert.ErrorContains(t, err, "unknown validation function", "Expected error for unknown function")
+		require.NotNil(t, trace, "Expected trace for unknown function")
+		assert.Equal(t, model.TraceSkipped, trace.Conditions()[1].Outcome, "Expected skipped condition after error")
+	})
+
+	t.Run("Canceled context", func(t *testing.T) {
+		v := NewValidator()
+		v.AddValidationFuncCtx(func(ctx context.Context, input any, astValue *model.AstValue) error {
+			return ctx.Err()
+		}, "check")
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := v.ExplainValueContext(ctx, "a", &model.Validation{Key: "field", Type: model.String, Requirement: "funcheck"})
+		assert.True(t, errors.Is(err, context.Canceled), "Expected canceled context error")
+	})
+}
+
+func TestExplainValueOutput(t *testing.T) {
+	trace, err := ExplainValue("ab", &model.Validation{Key: "name", Type: model.String, Requirement: "max0 || (min3 && max10)"})
+	require.NoError(t, err, "Expected no error explaining value")
+
+	assert.Equal(t, `name: max0 || (min3 && max10) with input "ab": failed: must be empty, or 3–10 characters long
+  failed max0 ||: value greater than maximum condition 0
+  failed group: value less than minimum condition 3
+    failed min3 &&: value less than minimum condition 3
+    skipped max10`, trace.String(), "Expected text of trace to match")
+
+	data, err := json.Marshal(trace)
+	require.NoError(t, err, "Expected no error marshalling trace")
+	result := map[string]any{}
+	require.NoError(t, json.Unmarshal(data, &result), "Expected valid json")
+	assert.Equal(t, "max0 || (min3 && max10)", result["requirement"], "Expected requirement in json")
+	assert.Equal(t, "ab", result["input"], "Expected input in json")
+	assert.Equal(t, "failed", result["outcome"], "Expected outcome in json")
+	assert.Equal(t, "must be empty, or 3–10 characters long", result["error"], "Expected error in json")
+	assert.Len(t, result["children"], 2, "Expected children in json")
+}
+
+func TestExplainStruct(t *testing.T) {
+	type Order struct {
+		ID     int    `json:"id" vld:"-"`
+		Status string `json:"status" vld:"frm['open', 'done'] || max0"`
+		Note   string `json:"note" vld:"max10"`
+	}
+
+	traces, err := ExplainStruct(&Order{Status: "new", Note: "fragile"})
+	require.NoError(t, err, "Expected no error explaining struct")
+	require.Len(t, traces, 2, "Expected traces of fields with requirement")
+	assert.Equal(t, "status", traces[0].Key, "Expected key of first field")
+	assert.Equal(t, model.TraceFailed, traces[0].Outcome, "Expected failed status")
+	assert.Equal(t, "note", traces[1].Key, "Expected key of second field")
+	assert.Equal(t, model.TracePassed, traces[1].Outcome, "Expected passed note")
+}
+
+func TestExplainWithValidation(t *testing.T) {
+	validations := []model.Validation{
+		{Key: "status", Type: model.String, Requirement: "frm['open', 'done']"},
+		{Key: "address", Type: model.Struct, Requirement: "-", InnerValidation: []model.Validation{
+			{Key: "city", Type: model.String, Requirement: "min1"},
+		}},
+		{Key: "items", Type: model.Array, Requirement: "min1", InnerValidation: []model.Validation{
+			{Key: "name", Type: model.String, Requirement: "min3"},
+		}},
+	}
+	jsonInput := map[string]any{
+		"status":  "open",
+		"address": map[string]any{"city": ""},
+		"items":   []any{map[string]any{"name": "book"}, map[string]any{"name": "x"}},
+	}
+
+	traces, err := ExplainWithValidation(jsonInput, validations)
+	require.NoError(t, err, "Expected no error explaining validations")
+
+	outcomes := map[string]model.TraceOutcome{}
+	for _, trace := range traces {
+		outcomes[trace.Key] = trace.Outcome
+	}
+	assert.Equal(t, map[string]model.TraceOutcome{
+		"status":        model.TracePassed,
+		"address.city":  model.TraceFailed,
+		"items[0].name": model.TracePassed,
+		"items[1].name": model.TraceFailed,
+	}, outcomes, "Expected outcomes of fields to match")
+}
@@ -1,32 +1,61 @@
 package validator
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/siherrmann/validator/helper"
 	"github.com/siherrmann/validator/model"
 )
 
+// GetValidationsFromStruct is the wrapper function for the GetValidationsFromStruct method of the Validator struct.
+// More details can be found in the GetValidationsFromStruct method.
+// The requirements are not parsed and type checked, because the wrapper does not know
+// the aliases, custom condition types and value lists of the Validator used for the validation.
+func GetValidationsFromStruct(in any, tagType string) ([]model.Validation, error) {
+	r := NewValidator()
+	return r.getValidationsFromStruct(in, tagType, false)
+}
+
+// GetValidationFromStructField is the wrapper function for the GetValidationFromStructField method of the Validator struct.
+// More details can be found in the GetValidationFromStructField method.
+// Like GetValidationsFromStruct it does not parse and type check the requirement.
+func GetValidationFromStructField(tagType string, fieldValue reflect.Value, fieldType reflect.StructField) (*model.Validation, error) {
+	r := NewValidator()
+	return r.getValidationFromStructField(tagType, fieldValue, fieldType, false)
+}
+
 // GetValidationsFromStruct extracts validation rules from a struct based on the provided tag type.
 // It iterates over the struct fields, checks for the specified tag type, and constructs Validation.
-func GetValidationsFromStruct(in any, tagType string) ([]model.Validation, error) {
+// The requirements are type checked (see GetValidationFromStructField) and the groups of the fields
+// have to be consistent (see checkGroups). It returns the errors of all fields at once.
+func (r *Validator) GetValidationsFromStruct(in any, tagType string) ([]model.Validation, error) {
+	return r.getValidationsFromStruct(in, tagType, true)
+}
+
+// getValidationsFromStruct extracts the validations like GetValidationsFromStruct,
+// the requirements are only parsed and type checked if checkRequirements is true.
+func (r *Validator) getValidationsFromStruct(in any, tagType string, checkRequirements bool) ([]model.Validation, error) {
 	err := helper.CheckValidPointerToStruct(in)
 	if err != nil {
 		return nil, err
 	}
 
 	validations := []model.Validation{}
+	errs := []error{}
 
 	structFull := reflect.ValueOf(in).Elem()
 	for i := 0; i < structFull.Type().NumField(); i++ {
 		field := structFull.Field(i)
 		fieldType := structFull.Type().Field(i)
 
-		validation, err := GetValidationFromStructField(tagType, field, fieldType)
+		validation, err := r.getValidationFromStructField(tagType, field, fieldType, checkRequirements)
 		if err != nil {
-			return nil, err
+			errs = append(errs, err)
+			continue
 		} else if validation == nil {
 			continue
 		}
@@ -35,13 +64,26 @@ func GetValidationsFromStruct(in any, tagType string) ([]model.Validation, error
 			validations = append(validations, *validation)
 		}
 	}
+
+	errs = append(errs, checkGroups(validations)...)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
 	return validations, nil
 }
 
 // GetValidationFromStructField extracts validation rules from a struct field based on the provided tag type.
 // It checks the field's tag for the specified tag type and constructs a Validation object.
 // If no json tag is found, it uses the field name as the key.
-func GetValidationFromStructField(tagType string, fieldValue reflect.Value, fieldType reflect.StructField) (*model.Validation, error) {
+// The requirement is parsed with the settings of the Validator (eg. aliases and custom condition types)
+// and the condition values have to be convertible to the type of the field (eg. `equabc` is invalid for an int).
+func (r *Validator) GetValidationFromStructField(tagType string, fieldValue reflect.Value, fieldType reflect.StructField) (*model.Validation, error) {
+	return r.getValidationFromStructField(tagType, fieldValue, fieldType, true)
+}
+
+// getValidationFromStructField extracts the validation like GetValidationFromStructField,
+// the requirement is only parsed and type checked if checkRequirements is true.
+func (r *Validator) getValidationFromStructField(tagType string, fieldValue reflect.Value, fieldType reflect.StructField, checkRequirements bool) (*model.Validation, error) {
 	validation := &model.Validation{}
 	validation.Key = fieldType.Name
 	if len(fieldType.Tag.Get("json")) > 0 {
@@ -53,43 +95,120 @@ func GetValidationFromStructField(tagType string, fieldValue reflect.Value, fiel
 		}
 	}
 	validation.Type = model.ReflectKindToValidatorType(fieldValue.Type().Kind())
+	validation.Enum, _ = helper.EnumValues(fieldValue.Type())
 	validation.Requirement = "-"
 
-	tagIndex := 0
-	tagSplit := strings.Split(fieldType.Tag.Get(string(tagType)), ", ")
-
-	if len(tagSplit) > tagIndex {
-		// Ignore if tag is empty, we do not want to validate this field at all
-		if len(tagSplit[tagIndex]) <= 3 && tagSplit[tagIndex] != "-" {
-			return nil, nil
+	if sourceTag, ok := fieldType.Tag.Lookup(model.SRC); ok {
+		var err error
+		validation.Source, validation.SourceName, err = model.GetSource(sourceTag)
+		if err != nil {
+			return nil, fmt.Errorf("error extracting source: %v", err)
 		}
-		validation.Requirement = tagSplit[tagIndex]
-		tagIndex++
 	}
 
-	if len(tagSplit) > tagIndex {
-		var err error
-		validation.Groups, err = model.GetGroups(tagSplit[tagIndex])
+	// Ignore if tag is empty, we do not want to validate this field at all.
+	tag, err := model.ParseTag(fieldType.Tag.Get(string(tagType)))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing tag of field %s: %v", fieldType.Name, err)
+	} else if tag == nil {
+		return nil, nil
+	}
+	validation.Requirement = tag.Requirement
+	validation.Groups = tag.Groups
+	validation.Default = tag.Default
+	validation.Message = tag.Message
+
+	if checkRequirements {
+		err = r.checkRequirement(validation, fieldValue.Type())
 		if err != nil {
-			return nil, fmt.Errorf("error extracting group: %v", err)
+			return nil, fmt.Errorf("error checking requirement of field %s: %w", fieldType.Name, err)
 		}
 	}
 
 	if helper.IsArrayOfStruct(fieldValue.Interface()) {
 		innerStruct := reflect.New(fieldValue.Type().Elem()).Interface()
-		innerValidation, err := GetValidationsFromStruct(innerStruct, string(tagType))
+		innerValidation, err := r.getValidationsFromStruct(innerStruct, string(tagType), checkRequirements)
 		if err != nil {
-			return nil, fmt.Errorf("error getting inner validation from array: %v", err)
+			return nil, fmt.Errorf("error getting inner validation from array: %w", err)
 		}
 		validation.InnerValidation = append(validation.InnerValidation, innerValidation...)
 	} else if helper.IsStruct(fieldValue.Interface()) {
 		innerStruct := reflect.New(fieldValue.Type()).Interface()
-		innerValidation, err := GetValidationsFromStruct(innerStruct, string(tagType))
+		innerValidation, err := r.getValidationsFromStruct(innerStruct, string(tagType), checkRequirements)
 		if err != nil {
-			return nil, fmt.Errorf("error getting inner validation from struct: %v", err)
+			return nil, fmt.Errorf("error getting inner validation from struct: %w", err)
 		}
 		validation.InnerValidation = append(validation.InnerValidation, innerValidation...)
 	}
 
 	return validation, nil
 }
+
+// checkRequirement parses the requirement of the validation and checks that the values of all conditions
+// are valid for the type of the field (see model.DiagnosticInvalidValue of AnalyzeRequirement).
+// The values of `con`, `nco`, `frm` and `nfr` are checked against the items of arrays and the keys of maps.
+func (r *Validator) checkRequirement(validation *model.Validation, fieldType reflect.Type) error {
+	rootNode, err := r.parseValidation(validation)
+	if err != nil {
+		return err
+	}
+
+	var itemType model.ValidatorType
+	switch fieldType.Kind() {
+	case reflect.Array, reflect.Slice:
+		itemType = model.ReflectKindToValidatorType(fieldType.Elem().Kind())
+	case reflect.Map:
+		itemType = model.ReflectKindToValidatorType(fieldType.Key().Kind())
+	}
+
+	errs := []error{}
+	for _, diagnostic := range analyzeRequirement(rootNode, validation.Type, itemType) {
+		if diagnostic.Kind == model.DiagnosticInvalidValue {
+			errs = append(errs, fmt.Errorf("condition %v: %v", diagnostic.Condition, diagnostic.Message))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkGroups checks that each group has the same condition on all fields, that the condition value
+// is a number and that a group with `min` has at least as many fields as needed.
+func checkGroups(validations []model.Validation) []error {
+	errs := []error{}
+	groups := map[string]*model.Group{}
+	groupKeys := map[string]string{}
+	groupSize := map[string]int{}
+	groupNames := []string{}
+	for _, validation := range validations {
+		for _, group := range validation.Groups {
+			groupSize[group.Name]++
+			first, ok := groups[group.Name]
+			if !ok {
+				groups[group.Name] = group
+				groupKeys[group.Name] = validation.Key
+				groupNames = append(groupNames, group.Name)
+			} else if first.ConditionType != group.ConditionType || first.ConditionValue != group.ConditionValue {
+				errs = append(errs, fmt.Errorf(
+					"inconsistent group %s: %s%s on field %s, but %s%s on field %s",
+					group.Name,
+					first.ConditionType,
+					first.ConditionValue,
+					groupKeys[group.Name],
+					group.ConditionType,
+					group.ConditionValue,
+					validation.Key,
+				))
+			}
+		}
+	}
+
+	for _, name := range groupNames {
+		group := groups[name]
+		value, err := strconv.Atoi(group.ConditionValue)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid value %s of group %s, expected a number", group.ConditionValue, name))
+		} else if group.ConditionType == model.MIN_VALUE && value > groupSize[name] {
+			errs = append(errs, fmt.Errorf("group %s needs %d fields without error, but has only %d fields", name, value, groupSize[name]))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,75 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+)
+
+// Handler returns a http.Handler that binds and validates the request into In by the given tagType
+// with UnmapOrUnmarshalValidateAndUpdate, calls the function with the request context and
+// writes the returned Out as json.
+//
+// Binding errors are answered like in Bind, invalid input with the ValidationStatus of the Validator.
+// Errors returned by the function are answered with the status code of the ErrorStatus function
+// of the Validator (or the status of a returned StatusError), http.StatusInternalServerError by default.
+// In can be a struct or a pointer to a struct, which is allocated for every request.
+// If the Validator is nil a new Validator is used.
+func Handler[In any, Out any](v *Validator, tagType string, fn func(ctx context.Context, in In) (Out, error)) http.Handler {
+	if v == nil {
+		v = NewValidator()
+	}
+	tagTypes := []string{}
+	if len(tagType) > 0 {
+		tagTypes = append(tagTypes, tagType)
+	}
+
+	inType := reflect.TypeOf((*In)(nil)).Elem()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var in In
+		var target any = &in
+		if inType.Kind() == reflect.Ptr {
+			value := reflect.New(inType.Elem())
+			in = value.Interface().(In)
+			target = value.Interface()
+		}
+
+		err := v.UnmapOrUnmarshalValidateAndUpdate(r, target, tagTypes...)
+		if err != nil {
+			v.respondError(w, r, err)
+			return
+		}
+
+		out, err := fn(r.Context(), in)
+		if err != nil {
+			v.respondError(w, r, v.handlerError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(out)
+	})
+}
+
+// handlerError wraps an error returned by a function of Handler into a StatusError
+// with the status code from the ErrorStatus function of the Validator.
+// A returned (wrapped) StatusError is kept as it is.
+func (r *Validator) handlerError(err error) error {
+	var statusError *StatusError
+	if errors.As(err, &statusError) {
+		return err
+	}
+
+	status := 0
+	if r.ErrorStatus != nil {
+		status = r.ErrorStatus(err)
+	}
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	return &StatusError{Status: status, Err: err}
+}
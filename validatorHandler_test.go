@@ -0,0 +1,100 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testOutput struct {
+	Greeting string `json:"greeting"`
+}
+
+var errTestNotFound = errors.New("not found")
+
+func TestHandler(t *testing.T) {
+	v := NewValidator()
+	v.ValidationStatus = http.StatusUnprocessableEntity
+	v.ErrorStatus = func(err error) int {
+		if errors.Is(err, errTestNotFound) {
+			return http.StatusNotFound
+		}
+		return 0
+	}
+
+	handler := Handler(v, "vld", func(ctx context.Context, in testStruct) (testOutput, error) {
+		switch in.Age {
+		case 3:
+			return testOutput{}, errTestNotFound
+		case 4:
+			return testOutput{}, errors.New("database unavailable")
+		case 5:
+			return testOutput{}, &StatusError{Status: http.StatusConflict, Err: errors.New("already exists")}
+		}
+		return testOutput{Greeting: "hello " + in.Name}, nil
+	})
+
+	tests := []struct {
+		name           string
+		body           string
+		contentType    string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"Valid input", `{"name":"apple","age":2}`, "application/json", http.StatusOK, `{"greeting":"hello apple"}`},
+		{"Invalid input", `{"name":"banana","age":2}`, "application/json", http.StatusUnprocessableEntity, ""},
+		{"Missing key", `{"age":2}`, "application/json", http.StatusUnprocessableEntity, ""},
+		{"Invalid json", `{"name":`, "application/json", http.StatusBadRequest, ""},
+		{"Unsupported media type", `name: apple`, "text/yaml", http.StatusUnsupportedMediaType, ""},
+		{"Mapped error", `{"name":"apple","age":3}`, "application/json", http.StatusNotFound, ""},
+		{"Unmapped error", `{"name":"apple","age":4}`, "application/json", http.StatusInternalServerError, ""},
+		{"Status error", `{"name":"apple","age":5}`, "application/json", http.StatusConflict, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/", bytes.NewBufferString(test.body))
+			require.NoError(t, err, "Expected no error creating request")
+			req.Header.Set("Content-Type", test.contentType)
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+			assert.Equal(t, test.expectedStatus, recorder.Code, "Expected status code to match")
+			if len(test.expectedBody) > 0 {
+				assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"), "Expected json content type")
+				assert.JSONEq(t, test.expectedBody, recorder.Body.String(), "Expected body to match")
+			}
+		})
+	}
+
+	t.Run("Server error message is hidden", func(t *testing.T) {
+		req, err := http.NewRequest("POST", "/", bytes.NewBufferString(`{"name":"apple","age":4}`))
+		require.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		assert.NotContains(t, recorder.Body.String(), "database unavailable", "Expected internal error not to be exposed")
+	})
+
+	t.Run("Pointer input", func(t *testing.T) {
+		handler := Handler(v, "vld", func(ctx context.Context, in *testStruct) (testOutput, error) {
+			return testOutput{Greeting: "hello " + in.Name}, nil
+		})
+
+		req, err := http.NewRequest("POST", "/", bytes.NewBufferString(`{"name":"apple","age":2}`))
+		require.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		assert.Equal(t, http.StatusOK, recorder.Code, "Expected status code to match")
+		assert.JSONEq(t, `{"greeting":"hello apple"}`, recorder.Body.String(), "Expected body to match")
+	})
+}
@@ -0,0 +1,119 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/siherrmann/validator/model"
+)
+
+// ErrorResponder writes the response for an error of binding or validating a request.
+type ErrorResponder func(w http.ResponseWriter, r *http.Request, err error)
+
+// StatusError is an error with the http status code it should be answered with.
+type StatusError struct {
+	Status int
+	Err    error
+}
+
+func (e *StatusError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// DefaultErrorResponder writes the error message as plain text with the status code from StatusCodeFromError.
+// For server errors (5xx) only the status text is written, so internal errors are not exposed.
+func DefaultErrorResponder(w http.ResponseWriter, r *http.Request, err error) {
+	status := StatusCodeFromError(err)
+	message := err.Error()
+	if status >= http.StatusInternalServerError {
+		message = http.StatusText(status)
+	}
+	http.Error(w, message, status)
+}
+
+// StatusCodeFromError returns the http status code for an error of binding or validating a request.
+// It returns the status of a StatusError, http.StatusUnsupportedMediaType for model.ErrUnsupportedMediaType,
+// http.StatusRequestEntityTooLarge for model.ErrLimitExceeded, http.StatusServiceUnavailable for
+// canceled contexts or exceeded deadlines and http.StatusBadRequest for validation errors and
+// invalid input (see model.ErrInvalidInput). All other errors (eg. invalid tags or unknown validation functions)
+// are errors of the server and return http.StatusInternalServerError.
+func StatusCodeFromError(err error) int {
+	var statusError *StatusError
+	switch {
+	case errors.As(err, &statusError):
+		return statusError.Status
+	case errors.Is(err, model.ErrUnsupportedMediaType):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, model.ErrLimitExceeded):
+		return http.StatusRequestEntityTooLarge
+	case model.IsContextError(err):
+		return http.StatusServiceUnavailable
+	case model.IsValidationError(err), errors.Is(err, model.ErrInvalidInput):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// SetErrorResponder sets the ErrorResponder used by Bind, nil uses DefaultErrorResponder.
+func (r *Validator) SetErrorResponder(responder ErrorResponder) {
+	r.ErrorResponder = responder
+}
+
+// respondError writes the error with the ErrorResponder of the Validator or DefaultErrorResponder.
+// Validation errors are wrapped into a StatusError with the ValidationStatus of the Validator if it is set.
+func (r *Validator) respondError(w http.ResponseWriter, request *http.Request, err error) {
+	if r.ValidationStatus > 0 && model.IsValidationError(err) {
+		err = &StatusError{Status: r.ValidationStatus, Err: err}
+	}
+
+	if r.ErrorResponder != nil {
+		r.ErrorResponder(w, request, err)
+		return
+	}
+	DefaultErrorResponder(w, request, err)
+}
+
+// contextKey is the key of a bound value of type T in a request context.
+type contextKey[T any] struct{}
+
+// NewContext returns a copy of the context with the bound value.
+func NewContext[T any](ctx context.Context, value *T) context.Context {
+	return context.WithValue(ctx, contextKey[T]{}, value)
+}
+
+// FromContext returns the value of type T bound by Bind from the context.
+// It returns false if no value of type T is in the context.
+func FromContext[T any](ctx context.Context) (*T, bool) {
+	value, ok := ctx.Value(contextKey[T]{}).(*T)
+	return value, ok && value != nil
+}
+
+// Bind returns a middleware that decodes the request into a new T, validates it by the given tagType
+// with UnmapOrUnmarshalValidateAndUpdate and stores it in the request context.
+// Handlers get the value with FromContext[T].
+// If binding fails the ErrorResponder of the Validator writes the response and the next handler is not called.
+// If the Validator is nil a new Validator is used.
+func Bind[T any](v *Validator, tagType ...string) func(http.Handler) http.Handler {
+	if v == nil {
+		v = NewValidator()
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			value := new(T)
+			err := v.UnmapOrUnmarshalValidateAndUpdate(r, value, tagType...)
+			if err != nil {
+				v.respondError(w, r, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), value)))
+		})
+	}
+}
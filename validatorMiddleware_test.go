@@ -0,0 +1,121 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/siherrmann/validator/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusCodeFromError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"Unsupported media type", fmt.Errorf("%w: text/plain", model.ErrUnsupportedMediaType), http.StatusUnsupportedMediaType},
+		{"Wrapped limit exceeded", fmt.Errorf("error unmarshaling: %w", fmt.Errorf("%w: too deep", model.ErrLimitExceeded)), http.StatusRequestEntityTooLarge},
+		{"Validation error", fmt.Errorf("error validating struct: %w", &model.ValidationError{Message: "value too short"}), http.StatusBadRequest},
+		{"Invalid input", fmt.Errorf("error unmarshaling request body: %w", &model.InputError{Err: fmt.Errorf("unexpected EOF")}), http.StatusBadRequest},
+		{"Server error", fmt.Errorf("error getting validations: unknown function checkName"), http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, StatusCodeFromError(test.err), "Expected status code to match")
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	ctx := NewContext(context.Background(), &testStruct{Name: "apple"})
+
+	value, ok := FromContext[testStruct](ctx)
+	assert.True(t, ok, "Expected value in context")
+	assert.Equal(t, "apple", value.Name, "Expected name to be 'apple'")
+
+	_, ok = FromContext[model.Validation](ctx)
+	assert.False(t, ok, "Expected no value of other type in context")
+}
+
+func TestBind(t *testing.T) {
+	v := NewValidator()
+	v.Limits = model.Limits{MaxBodyBytes: 64}
+
+	var bound *testStruct
+	handler := Bind[testStruct](v)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ok bool
+		bound, ok = FromContext[testStruct](r.Context())
+		require.True(t, ok, "Expected bound value in context")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name           string
+		body           string
+		contentType    string
+		expectedStatus int
+	}{
+		{"Valid JSON", `{"name":"apple","age":2}`, "application/json", http.StatusNoContent},
+		{"Invalid JSON values", `{"name":"banana","age":2}`, "application/json", http.StatusBadRequest},
+		{"Unsupported media type", `name: apple`, "text/yaml", http.StatusUnsupportedMediaType},
+		{"Body too large", `{"name":"` + strings.Repeat("a", 64) + `"}`, "application/json", http.StatusRequestEntityTooLarge},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bound = nil
+			req, err := http.NewRequest("POST", "/", bytes.NewBufferString(test.body))
+			require.NoError(t, err, "Expected no error creating request")
+			req.Header.Set("Content-Type", test.contentType)
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+			assert.Equal(t, test.expectedStatus, recorder.Code, "Expected status code to match")
+			if test.expectedStatus == http.StatusNoContent {
+				assert.Equal(t, &testStruct{Name: "apple", Age: 2}, bound, "Expected bound value to match")
+			} else {
+				assert.Nil(t, bound, "Expected next handler not to be called")
+			}
+		})
+	}
+
+	t.Run("Custom error responder", func(t *testing.T) {
+		v := NewValidator()
+		v.SetErrorResponder(func(w http.ResponseWriter, r *http.Request, err error) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		})
+		handler := Bind[testStruct](v, "vld")(http.NotFoundHandler())
+
+		req, err := http.NewRequest("POST", "/", bytes.NewBufferString(`{"name":"banana"}`))
+		require.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code, "Expected status code of custom responder")
+	})
+
+	t.Run("Invalid tag", func(t *testing.T) {
+		type invalidTagStruct struct {
+			Age int `json:"age" vld:"equabc"`
+		}
+		handler := Bind[invalidTagStruct](NewValidator())(http.NotFoundHandler())
+
+		req, err := http.NewRequest("POST", "/", bytes.NewBufferString(`{"age":2}`))
+		require.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code, "Expected server error for invalid tag")
+		assert.Equal(t, http.StatusText(http.StatusInternalServerError), strings.TrimSpace(recorder.Body.String()), "Expected generic body for server error")
+	})
+}
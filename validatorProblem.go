@@ -0,0 +1,43 @@
+package validator
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/siherrmann/validator/model"
+)
+
+// MediaTypeProblemJson is the media type of problem details documents (RFC 9457).
+const MediaTypeProblemJson = "application/problem+json"
+
+// NewProblem creates a problem details document (RFC 9457) for an error of binding or validating a request.
+// The status is taken from StatusCodeFromError and every invalid field is listed in the `errors` extension.
+// For server errors (5xx) the detail is left empty, so internal errors are not exposed.
+func NewProblem(err error) *model.Problem {
+	status := StatusCodeFromError(err)
+	problem := &model.Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Errors: model.ProblemErrorsFromError(err),
+	}
+	if status < http.StatusInternalServerError {
+		problem.Detail = err.Error()
+	}
+	return problem
+}
+
+// WriteProblem writes the error as application/problem+json document (see NewProblem).
+// The path of the request is used as instance.
+// It can be used directly in a http.HandlerFunc or as ErrorResponder (see SetErrorResponder).
+func WriteProblem(w http.ResponseWriter, r *http.Request, err error) {
+	problem := NewProblem(err)
+	if r != nil && r.URL != nil {
+		problem.Instance = r.URL.Path
+	}
+
+	w.Header().Set("Content-Type", MediaTypeProblemJson)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
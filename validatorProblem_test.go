@@ -0,0 +1,60 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/siherrmann/validator/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProblem(t *testing.T) {
+	v := NewValidator()
+	_, err := v.ValidateWithValidation(map[string]any{"name": "ab", "address": map[string]any{"city": ""}}, []model.Validation{
+		{Key: "name", Type: model.String, Requirement: "min3 || equab"},
+		{Key: "address", Type: model.Struct, Requirement: "-", InnerValidation: []model.Validation{
+			{Key: "city", Type: model.String, Requirement: "min1"},
+		}},
+	})
+	require.Error(t, err, "Expected validation error")
+
+	problem := NewProblem(err)
+	assert.Equal(t, http.StatusBadRequest, problem.Status, "Expected bad request status")
+	assert.Equal(t, "Bad Request", problem.Title, "Expected status text as title")
+	assert.Equal(t, []model.ProblemError{
+		{Field: "address.city", Rule: "min1", Message: "value less than minimum condition 1"},
+	}, problem.Errors, "Expected problem errors to match")
+
+	problem = NewProblem(&StatusError{Status: http.StatusInternalServerError, Err: errors.New("database unavailable")})
+	assert.Empty(t, problem.Detail, "Expected no detail for server errors")
+}
+
+func TestWriteProblem(t *testing.T) {
+	v := NewValidator()
+	v.ValidationStatus = http.StatusUnprocessableEntity
+	v.SetErrorResponder(WriteProblem)
+	handler := Bind[testStruct](v)(http.NotFoundHandler())
+
+	req, err := http.NewRequest("POST", "/fruits", bytes.NewBufferString(`{"name":"banana","age":2}`))
+	require.NoError(t, err, "Expected no error creating request")
+	req.Header.Set("Content-Type", "application/json")
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code, "Expected validation status")
+	assert.Equal(t, MediaTypeProblemJson, recorder.Header().Get("Content-Type"), "Expected problem json content type")
+
+	problem := &model.Problem{}
+	err = json.Unmarshal(recorder.Body.Bytes(), problem)
+	require.NoError(t, err, "Expected valid problem json")
+	assert.Equal(t, http.StatusUnprocessableEntity, problem.Status, "Expected status in problem")
+	assert.Equal(t, "/fruits", problem.Instance, "Expected request path as instance")
+	assert.Equal(t, []model.ProblemError{
+		{Field: "name", Rule: "equapple", Message: "value not equal condition apple"},
+	}, problem.Errors, "Expected problem errors to match")
+}
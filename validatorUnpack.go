@@ -1,37 +1,145 @@
 package validator
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
 	"net/http"
+	"reflect"
 
 	"github.com/siherrmann/validator/helper"
 	"github.com/siherrmann/validator/model"
 )
 
+// requestContext returns the context of the request or context.Background() if the request is nil.
+func requestContext(request *http.Request) context.Context {
+	if request == nil {
+		return context.Background()
+	}
+	return request.Context()
+}
+
+// requestBinding is the way a request body is decoded.
+type requestBinding int
+
+const (
+	bindJson requestBinding = iota
+	bindForm
+	bindDecoder
+)
+
+// getRequestBinding returns how the body of the request is decoded by its Content-Type.
+// Added decoders are used first, then application/json (and +json) is unmarshaled
+// and url encoded and multipart forms are unmapped.
+// Requests without a Content-Type are unmarshaled if they have a body and unmapped otherwise
+// (eg. with a form set by the caller), query parameters do not change the binding.
+// It returns an error wrapping model.ErrUnsupportedMediaType for all other media types.
+func (r *Validator) getRequestBinding(request *http.Request) (requestBinding, Decoder, error) {
+	if request == nil {
+		return bindJson, nil, fmt.Errorf("request is nil")
+	}
+
+	mediaType, err := helper.GetMediaType(request)
+	if err != nil {
+		return bindJson, nil, fmt.Errorf("%w: %v", model.ErrUnsupportedMediaType, err)
+	}
+
+	if decoder, ok := r.Decoders[mediaType]; ok && len(mediaType) > 0 {
+		return bindDecoder, decoder, nil
+	}
+
+	switch {
+	case len(mediaType) == 0:
+		hasBody, err := helper.HasRequestBody(request)
+		if err != nil {
+			return bindJson, nil, &model.InputError{Err: fmt.Errorf("error reading request body: %w", helper.LimitError(err))}
+		}
+		if hasBody {
+			return bindJson, nil, nil
+		}
+		return bindForm, nil, nil
+	case helper.IsJsonMediaType(mediaType):
+		return bindJson, nil, nil
+	case mediaType == helper.MediaTypeForm || mediaType == helper.MediaTypeMultipartForm:
+		return bindForm, nil, nil
+	default:
+		return bindJson, nil, fmt.Errorf("%w: %v", model.ErrUnsupportedMediaType, mediaType)
+	}
+}
+
 // UnmapOrUnmarshalAndValidate unmarshals given json ([]byte) or given url.Values (from request.Form),
 // validates them and updates the given struct.
+// The decoding is chosen by the Content-Type of the request (see AddDecoder for other media types).
 // It returns an error if the unmapping or validation fails.
 //
 // It is actually doing the same as UnmapOrUnmarshalValidateAndUpdate, but in another order.
 // It does directly update the struct and validates afterwards.
 // Normally you would either only use Validate or use UnmapOrUnmarshalValidateAndUpdate for early return on error.
 func (r *Validator) UnmapOrUnmarshalAndValidate(request *http.Request, structToUpdate any, tagType ...string) error {
-	err := request.ParseForm()
+	return r.UnmapOrUnmarshalAndValidateContext(requestContext(request), request, structToUpdate, tagType...)
+}
+
+// UnmapOrUnmarshalAndValidateContext does the same as UnmapOrUnmarshalAndValidate, but passes the given context instead of the
+// request context to the context aware validation functions (see AddValidationFuncCtx).
+func (r *Validator) UnmapOrUnmarshalAndValidateContext(ctx context.Context, request *http.Request, structToUpdate any, tagType ...string) error {
+	binding, decoder, err := r.getRequestBinding(request)
 	if err != nil {
 		return err
 	}
 
-	if len(request.Form.Encode()) > 0 {
-		err = r.UnmapAndValidate(request, structToUpdate, tagType...)
-	} else {
-		err = r.UnmarshalAndValidate(request, structToUpdate, tagType...)
+	switch binding {
+	case bindForm:
+		err = r.UnmapAndValidateContext(ctx, request, structToUpdate, tagType...)
+	case bindDecoder:
+		err = r.decodeAndValidate(ctx, request, decoder, structToUpdate, tagType...)
+	default:
+		err = r.UnmarshalAndValidateContext(ctx, request, structToUpdate, tagType...)
 	}
 
 	return err
 }
 
+// decodeRequest decodes the request body with the given decoder and checks the Limits of the decoded JsonMap.
+func (r *Validator) decodeRequest(request *http.Request, decoder Decoder) (map[string]any, error) {
+	helper.LimitRequestBody(request, r.Limits.MaxBodyBytes)
+	mapOut, err := decoder(request)
+	if err != nil {
+		return nil, &model.InputError{Err: fmt.Errorf("error decoding request body: %w", helper.LimitError(err))}
+	}
+
+	err = helper.CheckJsonMapLimits(mapOut, r.Limits)
+	if err != nil {
+		return nil, &model.InputError{Err: fmt.Errorf("error decoding request body: %w", err)}
+	}
+
+	return mapOut, nil
+}
+
+// decodeAndValidate decodes the request with the given decoder into a JsonMap and puts it into the given struct.
+// It validates the struct by the given tagType.
+func (r *Validator) decodeAndValidate(ctx context.Context, request *http.Request, decoder Decoder, structToValidate any, tagType ...string) error {
+	mapOut, err := r.decodeRequest(request, decoder)
+	if err != nil {
+		return err
+	}
+
+	err = helper.ApplyRequestSourcesByType(request, mapOut, reflect.TypeOf(structToValidate))
+	if err != nil {
+		return &model.InputError{Err: fmt.Errorf("error binding request sources: %v", err)}
+	}
+
+	err = helper.MapJsonMapToStruct(mapOut, structToValidate)
+	if err != nil {
+		return &model.InputError{Err: fmt.Errorf("error mapping json map to struct: %v", err)}
+	}
+
+	err = r.ValidateContext(ctx, structToValidate, tagType...)
+	if err != nil {
+		return fmt.Errorf("error validating struct: %w", err)
+	}
+
+	return nil
+}
+
 // UnmarshalAndValidate unmarshals given json ([]byte) into pointer v.
 // It validates the struct by the given tagType.
 // It returns an error if the unmarshaling or validation fails.
@@ -40,31 +148,42 @@ func (r *Validator) UnmapOrUnmarshalAndValidate(request *http.Request, structToU
 // It does directly update the struct and validates afterwards.
 // Normally you would either only use Validate or use UnmarshalValidateAndUpdate for early return on error.
 func (r *Validator) UnmarshalAndValidate(request *http.Request, structToValidate any, tagType ...string) error {
+	return r.UnmarshalAndValidateContext(requestContext(request), request, structToValidate, tagType...)
+}
+
+// UnmarshalAndValidateContext does the same as UnmarshalAndValidate, but passes the given context instead of the
+// request context to the context aware validation functions (see AddValidationFuncCtx).
+func (r *Validator) UnmarshalAndValidateContext(ctx context.Context, request *http.Request, structToValidate any, tagType ...string) error {
 	err := helper.CheckValidPointerToStruct(structToValidate)
 	if err != nil {
 		return err
 	}
 
-	var bodyBytes []byte
-	bodyBytes, err = io.ReadAll(request.Body)
+	mapOut, err := helper.UnmarshalRequestToJsonMapWithLimits(request, r.Limits)
 	if err != nil {
-		return err
+		return &model.InputError{Err: fmt.Errorf("error unmarshaling json: %w", err)}
 	}
 
-	err = json.Unmarshal(bodyBytes, structToValidate)
+	err = helper.ApplyRequestSourcesByType(request, mapOut, reflect.TypeOf(structToValidate))
 	if err != nil {
-		return fmt.Errorf("error unmarshaling json: %v", err)
+		return &model.InputError{Err: fmt.Errorf("error binding request sources: %v", err)}
 	}
 
-	err = r.Validate(structToValidate, tagType...)
+	err = helper.MapJsonMapToStruct(mapOut, structToValidate)
 	if err != nil {
-		return fmt.Errorf("error validating struct: %v", err)
+		return &model.InputError{Err: fmt.Errorf("error mapping json map to struct: %v", err)}
+	}
+
+	err = r.ValidateContext(ctx, structToValidate, tagType...)
+	if err != nil {
+		return fmt.Errorf("error validating struct: %w", err)
 	}
 
 	return nil
 }
 
 // UnmapAndValidate unmaps the url.Values from the request.Form into a JsonMap and puts it into the given struct.
+// The form values are converted by the field types of the struct.
 // It validates the struct by the given tagType.
 // It returns an error if the unmapping or validation fails.
 //
@@ -72,19 +191,34 @@ func (r *Validator) UnmarshalAndValidate(request *http.Request, structToValidate
 // It does directly update the struct and validates afterwards.
 // Normally you would either only use Validate or use UnmapValidateAndUpdate for early return on error.
 func (r *Validator) UnmapAndValidate(request *http.Request, structToValidate any, tagType ...string) error {
-	mapOut, err := helper.UnmapRequestToJsonMap(request)
+	return r.UnmapAndValidateContext(requestContext(request), request, structToValidate, tagType...)
+}
+
+// UnmapAndValidateContext does the same as UnmapAndValidate, but passes the given context instead of the
+// request context to the context aware validation functions (see AddValidationFuncCtx).
+func (r *Validator) UnmapAndValidateContext(ctx context.Context, request *http.Request, structToValidate any, tagType ...string) error {
+	helper.LimitRequestBody(request, r.Limits.MaxBodyBytes)
+	mapOut, err := helper.UnmapRequestToJsonMapByType(request, reflect.TypeOf(structToValidate), r.MaxFormIndex)
+	if err == nil {
+		err = helper.CheckJsonMapLimits(mapOut, r.Limits)
+	}
 	if err != nil {
-		return fmt.Errorf("error unmapping form values: %v", err)
+		return &model.InputError{Err: fmt.Errorf("error unmapping form values: %w", err)}
+	}
+
+	err = helper.ApplyRequestSourcesByType(request, mapOut, reflect.TypeOf(structToValidate))
+	if err != nil {
+		return &model.InputError{Err: fmt.Errorf("error binding request sources: %v", err)}
 	}
 
 	err = helper.MapJsonMapToStruct(mapOut, structToValidate)
 	if err != nil {
-		return fmt.Errorf("error mapping json map to struct: %v", err)
+		return &model.InputError{Err: fmt.Errorf("error mapping json map to struct: %v", err)}
 	}
 
-	err = r.Validate(structToValidate, tagType...)
+	err = r.ValidateContext(ctx, structToValidate, tagType...)
 	if err != nil {
-		return fmt.Errorf("error validating url values: %v", err)
+		return fmt.Errorf("error validating url values: %w", err)
 	}
 
 	return nil
@@ -92,19 +226,41 @@ func (r *Validator) UnmapAndValidate(request *http.Request, structToValidate any
 
 // UnmapOrUnmarshalValidateAndUpdate unmarshals given json ([]byte) or given url.Values (from request.Form),
 // validates them and updates the given struct.
+// The decoding is chosen by the Content-Type of the request (see AddDecoder for other media types).
 // It returns an error if the unmapping, validation or update fails.
 //
 // For more information look at ValidateAndUpdate.
 func (r *Validator) UnmapOrUnmarshalValidateAndUpdate(request *http.Request, structToUpdate any, tagType ...string) error {
-	err := request.ParseForm()
+	return r.UnmapOrUnmarshalValidateAndUpdateContext(requestContext(request), request, structToUpdate, tagType...)
+}
+
+// UnmapOrUnmarshalValidateAndUpdateContext does the same as UnmapOrUnmarshalValidateAndUpdate, but passes the given context instead of the
+// request context to the context aware validation functions (see AddValidationFuncCtx).
+func (r *Validator) UnmapOrUnmarshalValidateAndUpdateContext(ctx context.Context, request *http.Request, structToUpdate any, tagType ...string) error {
+	binding, decoder, err := r.getRequestBinding(request)
 	if err != nil {
 		return err
 	}
 
-	if len(request.Form.Encode()) > 0 {
-		err = r.UnmapValidateAndUpdate(request, structToUpdate, tagType...)
-	} else {
-		err = r.UnmarshalValidateAndUpdate(request, structToUpdate, tagType...)
+	switch binding {
+	case bindForm:
+		err = r.UnmapValidateAndUpdateContext(ctx, request, structToUpdate, tagType...)
+	case bindDecoder:
+		var mapOut map[string]any
+		mapOut, err = r.decodeRequest(request, decoder)
+		if err != nil {
+			return err
+		}
+		err = helper.ApplyRequestSourcesByType(request, mapOut, reflect.TypeOf(structToUpdate))
+		if err != nil {
+			return &model.InputError{Err: fmt.Errorf("error binding request sources: %v", err)}
+		}
+		err = r.ValidateAndUpdateContext(ctx, mapOut, structToUpdate, tagType...)
+		if err != nil {
+			return fmt.Errorf("error updating struct: %w", err)
+		}
+	default:
+		err = r.UnmarshalValidateAndUpdateContext(ctx, request, structToUpdate, tagType...)
 	}
 
 	return err
@@ -115,50 +271,99 @@ func (r *Validator) UnmapOrUnmarshalValidateAndUpdate(request *http.Request, str
 //
 // For more information look at ValidateAndUpdate.
 func (r *Validator) UnmarshalValidateAndUpdate(request *http.Request, structToUpdate any, tagType ...string) error {
-	mapOut, err := helper.UnmarshalRequestToJsonMap(request)
+	return r.UnmarshalValidateAndUpdateContext(requestContext(request), request, structToUpdate, tagType...)
+}
+
+// UnmarshalValidateAndUpdateContext does the same as UnmarshalValidateAndUpdate, but passes the given context instead of the
+// request context to the context aware validation functions (see AddValidationFuncCtx).
+func (r *Validator) UnmarshalValidateAndUpdateContext(ctx context.Context, request *http.Request, structToUpdate any, tagType ...string) error {
+	mapOut, err := helper.UnmarshalRequestToJsonMapWithLimits(request, r.Limits)
 	if err != nil {
-		return fmt.Errorf("error unmarshaling request body: %v", err)
+		return &model.InputError{Err: fmt.Errorf("error unmarshaling request body: %w", err)}
 	}
 
-	err = r.ValidateAndUpdate(mapOut, structToUpdate, tagType...)
+	err = helper.ApplyRequestSourcesByType(request, mapOut, reflect.TypeOf(structToUpdate))
 	if err != nil {
-		return fmt.Errorf("error updating struct: %v", err)
+		return &model.InputError{Err: fmt.Errorf("error binding request sources: %v", err)}
+	}
+
+	err = r.ValidateAndUpdateContext(ctx, mapOut, structToUpdate, tagType...)
+	if err != nil {
+		return fmt.Errorf("error updating struct: %w", err)
 	}
 
 	return nil
 }
 
 // UnmapValidateAndUpdate unmaps given url.Values into pointer jsonMap.
+// The form values are converted by the field types of the struct.
 // It returns an error if the unmapping, validation or update fails.
 //
 // For more information look at ValidateAndUpdate.
 func (r *Validator) UnmapValidateAndUpdate(request *http.Request, structToUpdate any, tagType ...string) error {
-	mapOut, err := helper.UnmapRequestToJsonMap(request)
+	return r.UnmapValidateAndUpdateContext(requestContext(request), request, structToUpdate, tagType...)
+}
+
+// UnmapValidateAndUpdateContext does the same as UnmapValidateAndUpdate, but passes the given context instead of the
+// request context to the context aware validation functions (see AddValidationFuncCtx).
+func (r *Validator) UnmapValidateAndUpdateContext(ctx context.Context, request *http.Request, structToUpdate any, tagType ...string) error {
+	helper.LimitRequestBody(request, r.Limits.MaxBodyBytes)
+	mapOut, err := helper.UnmapRequestToJsonMapByType(request, reflect.TypeOf(structToUpdate), r.MaxFormIndex)
+	if err == nil {
+		err = helper.CheckJsonMapLimits(mapOut, r.Limits)
+	}
+	if err != nil {
+		return &model.InputError{Err: fmt.Errorf("error unmapping form values: %w", err)}
+	}
+
+	err = helper.ApplyRequestSourcesByType(request, mapOut, reflect.TypeOf(structToUpdate))
 	if err != nil {
-		return fmt.Errorf("error unmapping form values: %v", err)
+		return &model.InputError{Err: fmt.Errorf("error binding request sources: %v", err)}
 	}
 
-	err = r.ValidateAndUpdate(mapOut, structToUpdate, tagType...)
+	err = r.ValidateAndUpdateContext(ctx, mapOut, structToUpdate, tagType...)
 	if err != nil {
-		return fmt.Errorf("error updating struct: %v", err)
+		return fmt.Errorf("error updating struct: %w", err)
 	}
 
 	return nil
 }
 
 // UnmapOrUnmarshalValidateAndUpdateWithValidation unmarshals given json ([]byte) or given url.Values (from request.Form).
+// The decoding is chosen by the Content-Type of the request (see AddDecoder for other media types).
 // It validates the map with the given validations and updates the given map.
 // It returns an error if the unmapping, validation or update fails.
 func (r *Validator) UnmapOrUnmarshalValidateAndUpdateWithValidation(request *http.Request, mapToUpdate *map[string]any, validations []model.Validation) error {
-	err := request.ParseForm()
+	return r.UnmapOrUnmarshalValidateAndUpdateWithValidationContext(requestContext(request), request, mapToUpdate, validations)
+}
+
+// UnmapOrUnmarshalValidateAndUpdateWithValidationContext does the same as UnmapOrUnmarshalValidateAndUpdateWithValidation, but passes the given context instead of the
+// request context to the context aware validation functions (see AddValidationFuncCtx).
+func (r *Validator) UnmapOrUnmarshalValidateAndUpdateWithValidationContext(ctx context.Context, request *http.Request, mapToUpdate *map[string]any, validations []model.Validation) error {
+	binding, decoder, err := r.getRequestBinding(request)
 	if err != nil {
 		return err
 	}
 
-	if len(request.Form.Encode()) > 0 {
-		err = r.UnmapValidateAndUpdateWithValidation(request, mapToUpdate, validations)
-	} else {
-		err = r.UnmarshalValidateAndUpdateWithValidation(request, mapToUpdate, validations)
+	switch binding {
+	case bindForm:
+		err = r.UnmapValidateAndUpdateWithValidationContext(ctx, request, mapToUpdate, validations)
+	case bindDecoder:
+		var mapOut map[string]any
+		mapOut, err = r.decodeRequest(request, decoder)
+		if err != nil {
+			return err
+		}
+		err = helper.ApplyRequestSourcesByValidation(request, mapOut, validations)
+		if err != nil {
+			return &model.InputError{Err: fmt.Errorf("error binding request sources: %v", err)}
+		}
+		err = r.ValidateAndUpdateWithValidationContext(ctx, mapOut, mapToUpdate, validations)
+		if err != nil {
+			return fmt.Errorf("error updating struct: %w", err)
+		}
+	default:
+		err = r.UnmarshalValidateAndUpdateWithValidationContext(ctx, request, mapToUpdate, validations)
 	}
 
 	return err
@@ -168,31 +373,58 @@ func (r *Validator) UnmapOrUnmarshalValidateAndUpdateWithValidation(request *htt
 // It validates the map by the given validations and updates it.
 // It returns an error if the unmarshaling, validation or update fails.
 func (r *Validator) UnmarshalValidateAndUpdateWithValidation(request *http.Request, mapToUpdate *map[string]any, validations []model.Validation) error {
-	mapOut, err := helper.UnmarshalRequestToJsonMap(request)
+	return r.UnmarshalValidateAndUpdateWithValidationContext(requestContext(request), request, mapToUpdate, validations)
+}
+
+// UnmarshalValidateAndUpdateWithValidationContext does the same as UnmarshalValidateAndUpdateWithValidation, but passes the given context instead of the
+// request context to the context aware validation functions (see AddValidationFuncCtx).
+func (r *Validator) UnmarshalValidateAndUpdateWithValidationContext(ctx context.Context, request *http.Request, mapToUpdate *map[string]any, validations []model.Validation) error {
+	mapOut, err := helper.UnmarshalRequestToJsonMapWithLimits(request, r.Limits)
 	if err != nil {
-		return fmt.Errorf("error unmarshaling request body: %v", err)
+		return &model.InputError{Err: fmt.Errorf("error unmarshaling request body: %w", err)}
 	}
 
-	err = r.ValidateAndUpdateWithValidation(mapOut, mapToUpdate, validations)
+	err = helper.ApplyRequestSourcesByValidation(request, mapOut, validations)
 	if err != nil {
-		return fmt.Errorf("error updating struct: %v", err)
+		return &model.InputError{Err: fmt.Errorf("error binding request sources: %v", err)}
+	}
+
+	err = r.ValidateAndUpdateWithValidationContext(ctx, mapOut, mapToUpdate, validations)
+	if err != nil {
+		return fmt.Errorf("error updating struct: %w", err)
 	}
 
 	return nil
 }
 
 // UnmapValidateAndUpdateWithValidation unmaps given url.Values into pointer jsonMap.
+// The form values are converted by the types of the validations.
 // It validates the map by the given validations and updates it.
 // It returns an error if the unmapping, validation or update fails.
 func (r *Validator) UnmapValidateAndUpdateWithValidation(request *http.Request, mapToUpdate *map[string]any, validations []model.Validation) error {
-	mapOut, err := helper.UnmapRequestToJsonMap(request)
+	return r.UnmapValidateAndUpdateWithValidationContext(requestContext(request), request, mapToUpdate, validations)
+}
+
+// UnmapValidateAndUpdateWithValidationContext does the same as UnmapValidateAndUpdateWithValidation, but passes the given context instead of the
+// request context to the context aware validation functions (see AddValidationFuncCtx).
+func (r *Validator) UnmapValidateAndUpdateWithValidationContext(ctx context.Context, request *http.Request, mapToUpdate *map[string]any, validations []model.Validation) error {
+	helper.LimitRequestBody(request, r.Limits.MaxBodyBytes)
+	mapOut, err := helper.UnmapRequestToJsonMapByValidation(request, validations, r.MaxFormIndex)
+	if err == nil {
+		err = helper.CheckJsonMapLimits(mapOut, r.Limits)
+	}
+	if err != nil {
+		return &model.InputError{Err: fmt.Errorf("error unmapping form values: %w", err)}
+	}
+
+	err = helper.ApplyRequestSourcesByValidation(request, mapOut, validations)
 	if err != nil {
-		return fmt.Errorf("error unmapping form values: %v", err)
+		return &model.InputError{Err: fmt.Errorf("error binding request sources: %v", err)}
 	}
 
-	err = r.ValidateAndUpdateWithValidation(mapOut, mapToUpdate, validations)
+	err = r.ValidateAndUpdateWithValidationContext(ctx, mapOut, mapToUpdate, validations)
 	if err != nil {
-		return fmt.Errorf("error updating struct: %v", err)
+		return fmt.Errorf("error updating struct: %w", err)
 	}
 
 	return nil
@@ -2,8 +2,14 @@ package validator
 
 import (
 	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
 	"net/http"
+	"net/http/httptest"
+	"net/textproto"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/siherrmann/validator/model"
@@ -189,6 +195,19 @@ func TestUnmapOrUnmarshalValidateAndUpdate(t *testing.T) {
 		assert.NoError(t, err, "Expected no error on unmap or unmarshal validate and update")
 	})
 
+	t.Run("Valid with request body and query without content type", func(t *testing.T) {
+		ts := &testStruct{}
+		req, _ := http.NewRequest("POST", "/x?page=2", bytes.NewBufferString(`{"name":"apple","age":3}`))
+
+		err := v.UnmapOrUnmarshalValidateAndUpdate(req, ts)
+		assert.NoError(t, err, "Expected request body to be unmarshaled despite query parameters")
+		assert.Equal(t, 3, ts.Age, "Expected age from request body")
+
+		req, _ = http.NewRequest("POST", "/x?page=2", bytes.NewBufferString(`{"name":"apple","age":3}`))
+		err = v.UnmapOrUnmarshalAndValidate(req, &testStruct{})
+		assert.NoError(t, err, "Expected request body to be unmarshaled despite query parameters")
+	})
+
 	t.Run("Invalid with request body", func(t *testing.T) {
 		req, _ := http.NewRequest("POST", "/", bytes.NewBufferString(`{"name":"banana","age":1}`))
 
@@ -196,6 +215,68 @@ func TestUnmapOrUnmarshalValidateAndUpdate(t *testing.T) {
 		assert.Error(t, err, "Expected error on unmap or unmarshal validate and update")
 		assert.Contains(t, err.Error(), "error updating struct", "Expected error to contain validation error")
 	})
+
+	t.Run("Valid json request with query parameters", func(t *testing.T) {
+		ts := &testStruct{}
+		req, _ := http.NewRequest("POST", "/?page=1", bytes.NewBufferString(`{"name":"apple","age":2}`))
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+		err := v.UnmapOrUnmarshalValidateAndUpdate(req, ts)
+		assert.NoError(t, err, "Expected json body to be used despite query parameters")
+		assert.Equal(t, "apple", ts.Name, "Expected name from json body")
+	})
+
+	t.Run("Valid multipart form", func(t *testing.T) {
+		ts := &testStruct{}
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		require.NoError(t, writer.WriteField("name", "apple"), "Expected no error writing field")
+		require.NoError(t, writer.WriteField("age", "2"), "Expected no error writing field")
+		require.NoError(t, writer.Close(), "Expected no error closing writer")
+		req, _ := http.NewRequest("POST", "/", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+
+		err := v.UnmapOrUnmarshalValidateAndUpdate(req, ts)
+		assert.NoError(t, err, "Expected no error on multipart form")
+		assert.Equal(t, 2, ts.Age, "Expected age from multipart form")
+	})
+
+	t.Run("Valid with added decoder", func(t *testing.T) {
+		v := NewValidator()
+		v.AddDecoder(func(request *http.Request) (map[string]any, error) {
+			return map[string]any{"name": "apple", "age": 3}, nil
+		}, "application/x-test")
+		ts := &testStruct{}
+		req, _ := http.NewRequest("POST", "/", bytes.NewBufferString(`test`))
+		req.Header.Set("Content-Type", "application/x-test")
+
+		err := v.UnmapOrUnmarshalValidateAndUpdate(req, ts)
+		assert.NoError(t, err, "Expected no error with added decoder")
+		assert.Equal(t, 3, ts.Age, "Expected age from decoder")
+	})
+
+	t.Run("Unsupported media type", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/", bytes.NewBufferString(`name=apple`))
+		req.Header.Set("Content-Type", "text/plain")
+
+		err := v.UnmapOrUnmarshalValidateAndUpdate(req, ts)
+		assert.Error(t, err, "Expected error for unsupported media type")
+		assert.ErrorIs(t, err, model.ErrUnsupportedMediaType, "Expected unsupported media type error")
+
+		err = v.UnmapOrUnmarshalAndValidate(req, ts)
+		assert.ErrorIs(t, err, model.ErrUnsupportedMediaType, "Expected unsupported media type error")
+
+		err = v.UnmapOrUnmarshalValidateAndUpdateWithValidation(req, &map[string]any{}, []model.Validation{})
+		assert.ErrorIs(t, err, model.ErrUnsupportedMediaType, "Expected unsupported media type error")
+	})
+
+	t.Run("Invalid content type", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/", bytes.NewBufferString(`{}`))
+		req.Header.Set("Content-Type", "application/json; =")
+
+		err := v.UnmapOrUnmarshalValidateAndUpdate(req, ts)
+		assert.ErrorIs(t, err, model.ErrUnsupportedMediaType, "Expected unsupported media type error")
+	})
 }
 
 func TestUnmarshalValidateAndUpdate(t *testing.T) {
@@ -289,6 +370,74 @@ func TestUnmapValidateAndUpdate(t *testing.T) {
 		assert.Error(t, err, "Expected error on unmap validate and update with invalid struct type")
 		assert.Contains(t, err.Error(), "value has to be of kind pointer", "Expected error to contain validation error")
 	})
+
+	t.Run("Valid form values converted by field type", func(t *testing.T) {
+		ts := &struct {
+			Name   string `json:"name" vld:"min3"`
+			Zip    string `json:"zip" vld:"rex^[0-9]{5}$"`
+			Active bool   `json:"active" vld:"-"`
+		}{}
+		form := url.Values{}
+		form.Set("name", "123")
+		form.Set("zip", "01234")
+		form["active"] = []string{"false", "on"}
+		req, err := http.NewRequest("POST", "/", bytes.NewBufferString(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		require.NoError(t, err, "Expected no error creating request")
+
+		err = v.UnmapValidateAndUpdate(req, ts)
+		assert.NoError(t, err, "Expected no error on unmap validate and update")
+		assert.Equal(t, "123", ts.Name, "Expected name to stay a string")
+		assert.Equal(t, "01234", ts.Zip, "Expected zip to keep the leading zero")
+		assert.True(t, ts.Active, "Expected checkbox to be checked")
+	})
+
+	t.Run("Valid nested and indexed form values", func(t *testing.T) {
+		ts := &struct {
+			Address struct {
+				City string `json:"city" vld:"min1"`
+			} `json:"address" vld:"-"`
+			Items []struct {
+				Name string `json:"name" vld:"min3"`
+			} `json:"items" vld:"min2"`
+			Tags []string `json:"tags" vld:"min1"`
+		}{}
+		form := url.Values{}
+		form.Set("address.city", "Berlin")
+		form.Set("items[0].name", "foo")
+		form.Set("items[1].name", "bar")
+		form["tags[]"] = []string{"a", "b"}
+		req, err := http.NewRequest("POST", "/", bytes.NewBufferString(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		require.NoError(t, err, "Expected no error creating request")
+
+		err = v.UnmapValidateAndUpdate(req, ts)
+		assert.NoError(t, err, "Expected no error on unmap validate and update")
+		assert.Equal(t, "Berlin", ts.Address.City, "Expected nested city to be set")
+		require.Len(t, ts.Items, 2, "Expected two items")
+		assert.Equal(t, "foo", ts.Items[0].Name, "Expected first item name")
+		assert.Equal(t, "bar", ts.Items[1].Name, "Expected second item name")
+		assert.Equal(t, []string{"a", "b"}, ts.Tags, "Expected tags")
+	})
+
+	t.Run("Invalid form index", func(t *testing.T) {
+		v := NewValidator()
+		v.MaxFormIndex = 5
+		ts := &struct {
+			Items []struct {
+				Name string `json:"name" vld:"min3"`
+			} `json:"items" vld:"min1"`
+		}{}
+		form := url.Values{}
+		form.Set("items[6].name", "foo")
+		req, err := http.NewRequest("POST", "/", bytes.NewBufferString(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		require.NoError(t, err, "Expected no error creating request")
+
+		err = v.UnmapValidateAndUpdate(req, ts)
+		assert.Error(t, err, "Expected error for index out of range")
+		assert.Contains(t, err.Error(), "out of range", "Expected out of range error")
+	})
 }
 
 func TestUnmapOrUnmarshalValidateAndUpdateWithValidation(t *testing.T) {
@@ -412,3 +561,291 @@ func TestUnmapValidateAndUpdateWithValidation(t *testing.T) {
 		assert.Contains(t, err.Error(), "error updating struct", "Expected error to contain validation error")
 	})
 }
+
+func TestUnmapOrUnmarshalValidateAndUpdateWithFiles(t *testing.T) {
+	v := newValidator()
+	png := []byte("\x89PNG\r\n\x1a\n0000")
+
+	type upload struct {
+		Title       string                  `json:"title" vld:"min1"`
+		Avatar      *multipart.FileHeader   `json:"avatar" vld:"fmx1KB snfimage/png fnm\\.png$, gr1min1"`
+		Attachments []*multipart.FileHeader `json:"attachments" vld:"max2 mimtext/*, gr1min1"`
+	}
+
+	newRequest := func(t *testing.T, files map[string][]string) *http.Request {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		require.NoError(t, writer.WriteField("title", "holiday"), "Expected no error writing field")
+		for field, names := range files {
+			for _, name := range names {
+				header := textproto.MIMEHeader{}
+				header.Set("Content-Disposition", `form-data; name="`+field+`"; filename="`+name+`"`)
+				if field == "avatar" {
+					header.Set("Content-Type", "image/png")
+				} else {
+					header.Set("Content-Type", "text/plain")
+				}
+				part, err := writer.CreatePart(header)
+				require.NoError(t, err, "Expected no error creating part")
+				if strings.HasSuffix(name, ".png") && !strings.HasPrefix(name, "fake") {
+					_, err = part.Write(png)
+				} else {
+					_, err = part.Write([]byte("hello world"))
+				}
+				require.NoError(t, err, "Expected no error writing part")
+			}
+		}
+		require.NoError(t, writer.Close(), "Expected no error closing writer")
+
+		req, err := http.NewRequest("POST", "/", body)
+		require.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		return req
+	}
+
+	t.Run("Valid single file", func(t *testing.T) {
+		u := &upload{}
+		err := v.UnmapOrUnmarshalValidateAndUpdate(newRequest(t, map[string][]string{"avatar": {"me.png"}}), u)
+		assert.NoError(t, err, "Expected no error on valid file")
+		require.NotNil(t, u.Avatar, "Expected avatar to be bound")
+		assert.Equal(t, "me.png", u.Avatar.Filename, "Expected avatar file name")
+		assert.Equal(t, "holiday", u.Title, "Expected title from form")
+	})
+
+	t.Run("Valid multiple files", func(t *testing.T) {
+		u := &upload{}
+		err := v.UnmapOrUnmarshalValidateAndUpdate(newRequest(t, map[string][]string{"attachments": {"a.txt", "b.txt"}}), u)
+		assert.NoError(t, err, "Expected no error on valid files")
+		assert.Len(t, u.Attachments, 2, "Expected two attachments")
+	})
+
+	t.Run("Invalid file count", func(t *testing.T) {
+		err := v.UnmapOrUnmarshalValidateAndUpdate(newRequest(t, map[string][]string{"attachments": {"a.txt", "b.txt", "c.txt"}}), &upload{})
+		assert.Error(t, err, "Expected error on too many files")
+	})
+
+	t.Run("Invalid sniffed file", func(t *testing.T) {
+		err := v.UnmapOrUnmarshalValidateAndUpdate(newRequest(t, map[string][]string{"avatar": {"fake.png"}}), &upload{})
+		assert.Error(t, err, "Expected error on file with text content")
+	})
+
+	t.Run("Invalid group without files", func(t *testing.T) {
+		err := v.UnmapOrUnmarshalValidateAndUpdate(newRequest(t, nil), &upload{})
+		assert.Error(t, err, "Expected error on missing files of group")
+	})
+}
+
+func TestUnmapOrUnmarshalValidateAndUpdateWithSources(t *testing.T) {
+	v := newValidator()
+
+	type updateUser struct {
+		ID        int    `json:"id" src:"path" vld:"min1"`
+		RequestID string `json:"request_id" src:"header:X-Request-ID" vld:"min3"`
+		DryRun    bool   `json:"dry_run" src:"query" vld:"-"`
+		Name      string `json:"name" vld:"min1"`
+	}
+
+	serve := func(t *testing.T, req *http.Request, bind func(r *http.Request, u *updateUser) error) (*updateUser, error) {
+		var err error
+		u := &updateUser{}
+		mux := http.NewServeMux()
+		mux.HandleFunc("PUT /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+			err = bind(r, u)
+		})
+		mux.ServeHTTP(httptest.NewRecorder(), req)
+		return u, err
+	}
+
+	t.Run("Valid JSON with sources", func(t *testing.T) {
+		req, err := http.NewRequest("PUT", "/users/42?dry_run=true", bytes.NewBufferString(`{"id":1,"request_id":"body","name":"apple"}`))
+		require.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Request-ID", "abc")
+
+		u, err := serve(t, req, func(r *http.Request, u *updateUser) error {
+			return v.UnmapOrUnmarshalValidateAndUpdate(r, u)
+		})
+		assert.NoError(t, err, "Expected no error binding sources")
+		assert.Equal(t, &updateUser{ID: 42, RequestID: "abc", DryRun: true, Name: "apple"}, u, "Expected body not to override sources")
+	})
+
+	t.Run("Valid form with sources", func(t *testing.T) {
+		form := url.Values{}
+		form.Set("id", "1")
+		form.Set("name", "apple")
+		req, err := http.NewRequest("PUT", "/users/42", bytes.NewBufferString(form.Encode()))
+		require.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("X-Request-ID", "abc")
+
+		u, err := serve(t, req, func(r *http.Request, u *updateUser) error {
+			return v.UnmapOrUnmarshalAndValidate(r, u)
+		})
+		assert.NoError(t, err, "Expected no error binding sources")
+		assert.Equal(t, 42, u.ID, "Expected id from path")
+		assert.Equal(t, "abc", u.RequestID, "Expected request id from header")
+	})
+
+	t.Run("Valid JSON and validate with sources", func(t *testing.T) {
+		req, err := http.NewRequest("PUT", "/users/42", bytes.NewBufferString(`{"id":1,"name":"apple"}`))
+		require.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Request-ID", "abc")
+
+		u, err := serve(t, req, func(r *http.Request, u *updateUser) error {
+			return v.UnmarshalAndValidate(r, u)
+		})
+		assert.NoError(t, err, "Expected no error binding sources")
+		assert.Equal(t, 42, u.ID, "Expected id from path")
+	})
+
+	t.Run("Invalid JSON with sources exceeding limits", func(t *testing.T) {
+		v := newValidator()
+		v.Limits = model.Limits{MaxStringLength: 5}
+		req, err := http.NewRequest("PUT", "/users/42", bytes.NewBufferString(`{"id":"`+strings.Repeat("1", 10)+`","name":"apple"}`))
+		require.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Request-ID", "abc")
+
+		_, err = serve(t, req, func(r *http.Request, u *updateUser) error {
+			return v.UnmarshalAndValidate(r, u)
+		})
+		assert.ErrorIs(t, err, model.ErrLimitExceeded, "Expected limits to be checked for keys of sources")
+	})
+
+	t.Run("Invalid missing header", func(t *testing.T) {
+		req, err := http.NewRequest("PUT", "/users/42", bytes.NewBufferString(`{"request_id":"from body","name":"apple"}`))
+		require.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		_, err = serve(t, req, func(r *http.Request, u *updateUser) error {
+			return v.UnmapOrUnmarshalValidateAndUpdate(r, u)
+		})
+		assert.Error(t, err, "Expected error on missing header")
+	})
+
+	t.Run("Valid map with source validations", func(t *testing.T) {
+		validations := []model.Validation{
+			{Key: "id", Type: model.Int, Requirement: "min1", Source: model.SourcePath},
+			{Key: "name", Type: model.String, Requirement: "min1"},
+		}
+		mapToUpdate := &map[string]any{}
+		req, err := http.NewRequest("PUT", "/users/42", bytes.NewBufferString(`{"id":1,"name":"apple"}`))
+		require.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		_, err = serve(t, req, func(r *http.Request, u *updateUser) error {
+			return v.UnmapOrUnmarshalValidateAndUpdateWithValidation(r, mapToUpdate, validations)
+		})
+		assert.NoError(t, err, "Expected no error binding sources")
+		assert.Equal(t, map[string]any{"id": int64(42), "name": "apple"}, *mapToUpdate, "Expected id from path")
+	})
+}
+
+func TestUnmapOrUnmarshalValidateAndUpdateWithLimits(t *testing.T) {
+	v := newValidator()
+	v.Limits = model.Limits{MaxBodyBytes: 64, MaxStringLength: 10}
+
+	t.Run("Valid JSON within limits", func(t *testing.T) {
+		req, err := http.NewRequest("POST", "/", bytes.NewBufferString(`{"name":"apple","age":2}`))
+		require.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		err = v.UnmapOrUnmarshalValidateAndUpdate(req, &testStruct{})
+		assert.NoError(t, err, "Expected no error within limits")
+	})
+
+	t.Run("Invalid JSON body too large", func(t *testing.T) {
+		req, err := http.NewRequest("POST", "/", bytes.NewBufferString(`{"name":"apple","age":2,"other":"`+strings.Repeat("a", 64)+`"}`))
+		require.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		err = v.UnmapOrUnmarshalValidateAndUpdate(req, &testStruct{})
+		assert.ErrorIs(t, err, model.ErrLimitExceeded, "Expected limit error for large body")
+	})
+
+	t.Run("Invalid JSON string too long", func(t *testing.T) {
+		req, err := http.NewRequest("POST", "/", bytes.NewBufferString(`{"name":"apple apple apple"}`))
+		require.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		err = v.UnmarshalAndValidate(req, &testStruct{})
+		assert.ErrorIs(t, err, model.ErrLimitExceeded, "Expected limit error for long string")
+	})
+
+	t.Run("Invalid form string too long", func(t *testing.T) {
+		form := url.Values{}
+		form.Set("name", "apple apple apple")
+		req, err := http.NewRequest("POST", "/", bytes.NewBufferString(form.Encode()))
+		require.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		err = v.UnmapOrUnmarshalValidateAndUpdate(req, &testStruct{})
+		assert.ErrorIs(t, err, model.ErrLimitExceeded, "Expected limit error for long form value")
+	})
+
+	t.Run("Invalid decoded value too long", func(t *testing.T) {
+		v := newValidator()
+		v.Limits = model.Limits{MaxStringLength: 10}
+		v.AddDecoder(func(r *http.Request) (map[string]any, error) {
+			return map[string]any{"name": "apple apple apple"}, nil
+		}, "text/plain")
+		req, err := http.NewRequest("POST", "/", bytes.NewBufferString(`apple`))
+		require.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "text/plain")
+
+		err = v.UnmapOrUnmarshalValidateAndUpdateWithValidation(req, &map[string]any{}, []model.Validation{{Key: "name", Requirement: "min1"}})
+		assert.ErrorIs(t, err, model.ErrLimitExceeded, "Expected limit error for long decoded value")
+	})
+}
+
+func TestUnmapOrUnmarshalValidateAndUpdateWithRequestContext(t *testing.T) {
+	v := newValidator()
+	v.AddValidationFuncCtx(func(ctx context.Context, input any, astValue *model.AstValue) error {
+		if ctx.Value(testTenantKey{}) != "tenant1" {
+			return fmt.Errorf("unknown tenant")
+		}
+		return nil
+	}, "Tenant")
+
+	type user struct {
+		Name string `json:"name" vld:"funTenant"`
+	}
+
+	newRequest := func(t *testing.T, ctx context.Context) *http.Request {
+		req, err := http.NewRequestWithContext(ctx, "POST", "/", bytes.NewBufferString(`{"name":"apple"}`))
+		require.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	err := v.UnmapOrUnmarshalValidateAndUpdate(newRequest(t, context.WithValue(context.Background(), testTenantKey{}, "tenant1")), &user{})
+	assert.NoError(t, err, "Expected request context to be passed")
+
+	err = v.UnmapOrUnmarshalValidateAndUpdate(newRequest(t, context.Background()), &user{})
+	assert.Error(t, err, "Expected error without tenant in request context")
+
+	err = v.UnmapOrUnmarshalValidateAndUpdateContext(context.WithValue(context.Background(), testTenantKey{}, "tenant1"), newRequest(t, context.Background()), &user{})
+	assert.NoError(t, err, "Expected given context to replace the request context")
+
+	t.Run("Added decoder", func(t *testing.T) {
+		v.AddDecoder(func(request *http.Request) (map[string]any, error) {
+			return map[string]any{"name": "apple"}, nil
+		}, "application/x-test")
+
+		newDecoderRequest := func(t *testing.T, ctx context.Context) *http.Request {
+			req := newRequest(t, ctx)
+			req.Header.Set("Content-Type", "application/x-test")
+			return req
+		}
+
+		err := v.UnmapOrUnmarshalAndValidate(newDecoderRequest(t, context.WithValue(context.Background(), testTenantKey{}, "tenant1")), &user{})
+		assert.NoError(t, err, "Expected request context to be passed with decoder")
+
+		err = v.UnmapOrUnmarshalAndValidate(newDecoderRequest(t, context.Background()), &user{})
+		assert.Error(t, err, "Expected error without tenant in request context with decoder")
+
+		err = v.UnmapOrUnmarshalAndValidateContext(context.WithValue(context.Background(), testTenantKey{}, "tenant1"), newDecoderRequest(t, context.Background()), &user{})
+		assert.NoError(t, err, "Expected given context to replace the request context with decoder")
+	})
+}
@@ -27,6 +27,13 @@ func ValidateAndUpdate(jsonInput map[string]any, structToUpdate any, tagType ...
 	return r.ValidateAndUpdate(jsonInput, structToUpdate, tagType...)
 }
 
+// ValidateAndUpdateWithChanges is the wrapper function for the ValidateAndUpdateWithChanges method of the Validator struct.
+// More details can be found in the ValidateAndUpdateWithChanges method.
+func ValidateAndUpdateWithChanges(jsonInput map[string]any, structToUpdate any, tagType ...string) (model.ChangeSet, error) {
+	r := NewValidator()
+	return r.ValidateAndUpdateWithChanges(jsonInput, structToUpdate, tagType...)
+}
+
 // ValidateAndUpdateWithValidation is the wrapper function for the ValidateAndUpdateWithValidation method of the Validator struct.
 // More details can be found in the ValidateAndUpdateWithValidation method.
 func ValidateAndUpdateWithValidation(jsonInput map[string]any, mapToUpdate *map[string]any, validations []model.Validation) error {
@@ -96,3 +103,31 @@ func UnmarshalValidateAndUpdateWithValidation(request *http.Request, mapToUpdate
 	r := NewValidator()
 	return r.UnmarshalValidateAndUpdateWithValidation(request, mapToUpdate, validations)
 }
+
+// DescribeRequirement is the wrapper function for the DescribeRequirement method of the Validator struct.
+// More details can be found in the DescribeRequirement method.
+func DescribeRequirement(rootNode model.RootNode, validatorType model.ValidatorType) string {
+	r := NewValidator()
+	return r.DescribeRequirement(rootNode, validatorType)
+}
+
+// ExplainValue is the wrapper function for the ExplainValue method of the Validator struct.
+// More details can be found in the ExplainValue method.
+func ExplainValue(input any, validation *model.Validation) (*model.Trace, error) {
+	r := NewValidator()
+	return r.ExplainValue(input, validation)
+}
+
+// ExplainStruct is the wrapper function for the ExplainStruct method of the Validator struct.
+// More details can be found in the ExplainStruct method.
+func ExplainStruct(v any, tagType ...string) ([]*model.Trace, error) {
+	r := NewValidator()
+	return r.ExplainStruct(v, tagType...)
+}
+
+// ExplainWithValidation is the wrapper function for the ExplainWithValidation method of the Validator struct.
+// More details can be found in the ExplainWithValidation method.
+func ExplainWithValidation(jsonInput map[string]any, validations []model.Validation) ([]*model.Trace, error) {
+	r := NewValidator()
+	return r.ExplainWithValidation(jsonInput, validations)
+}
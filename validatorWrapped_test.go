@@ -40,6 +40,15 @@ func TestWrappedValidateAndUpdate(t *testing.T) {
 	assert.Equal(t, 2, s.Age, "Expected age to be 2")
 }
 
+func TestWrappedValidateAndUpdateWithChanges(t *testing.T) {
+	jsonInput := map[string]any{"name": "apple", "age": 3}
+	s := testStruct{Name: "apple", Age: 2}
+
+	changes, err := ValidateAndUpdateWithChanges(jsonInput, &s)
+	assert.NoError(t, err, "Expected no error on validate and update with changes")
+	assert.Equal(t, model.ChangeSet{{Path: "age", Old: 2, New: 3}}, changes, "Expected age to be changed")
+}
+
 func TestWrappedValidateAndUpdateWithValidation(t *testing.T) {
 	jsonInput := map[string]any{"name": "apple", "age": 2}
 	mapToUpdate := map[string]any{}
@@ -1,9 +1,16 @@
 package validator
 
 import (
+	"context"
 	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/siherrmann/validator/helper"
 	"github.com/siherrmann/validator/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -196,6 +203,52 @@ func TestValidateAndUpdate(t *testing.T) {
 	})
 }
 
+func TestValidateAndUpdateWithChanges(t *testing.T) {
+	r := NewValidator()
+
+	type Address struct {
+		City string `json:"city" upd:"min1"`
+		Zip  string `json:"zip" upd:"min5"`
+	}
+	type TestStruct struct {
+		ID      int     `json:"id"`
+		Name    string  `json:"name" upd:"min1, gr1min1"`
+		Age     int     `json:"age" upd:"min18, gr1min1"`
+		Address Address `json:"address" upd:"-"`
+	}
+
+	t.Run("Valid update with changes", func(t *testing.T) {
+		testStruct := &TestStruct{ID: 1, Name: "John", Age: 30, Address: Address{City: "Berlin", Zip: "10115"}}
+		changes, err := r.ValidateAndUpdateWithChanges(map[string]any{
+			"name":    "John",
+			"age":     31,
+			"address": map[string]any{"city": "Hamburg", "zip": "10115"},
+		}, testStruct, "upd")
+		assert.NoError(t, err, "Expected no error but got one")
+		assert.Equal(t, model.ChangeSet{
+			{Path: "age", Old: 30, New: 31},
+			{Path: "address.city", Old: "Berlin", New: "Hamburg"},
+		}, changes, "Expected only changed values")
+		assert.Equal(t, []string{"age", "address"}, changes.Columns(), "Expected changed columns")
+		assert.Equal(t, 1, testStruct.ID, "Expected id to be unchanged")
+	})
+
+	t.Run("Invalid update", func(t *testing.T) {
+		testStruct := &TestStruct{Name: "John", Age: 30}
+		changes, err := r.ValidateAndUpdateWithChanges(map[string]any{"age": 12}, testStruct, "upd")
+		assert.Error(t, err, "Expected an error but got none")
+		assert.Nil(t, changes, "Expected no changes")
+		assert.Equal(t, 30, testStruct.Age, "Expected output to be unchanged")
+	})
+
+	t.Run("Invalid struct pointer", func(t *testing.T) {
+		changes, err := r.ValidateAndUpdateWithChanges(map[string]any{"name": "Jane"}, TestStruct{}, "upd")
+		require.Error(t, err, "Expected an error for invalid struct")
+		assert.Nil(t, changes, "Expected no changes")
+		assert.Contains(t, err.Error(), "value has to be of kind pointer", "Expected error to contain 'value has to be of kind pointer'")
+	})
+}
+
 func TestValidateAndUpdateWithValidation(t *testing.T) {
 	type args struct {
 		jsonMap     map[string]any
@@ -261,6 +314,41 @@ func TestValidateAndUpdateWithValidation(t *testing.T) {
 	}
 }
 
+func TestValidateAndUpdateWithValidationMergeStrategy(t *testing.T) {
+	validations := []model.Validation{
+		{Key: "address", Type: model.Struct, Requirement: "-", InnerValidation: []model.Validation{
+			{Key: "city", Type: model.String, Requirement: "min1"},
+		}},
+	}
+
+	t.Run("Default replaces nested map", func(t *testing.T) {
+		r := NewValidator()
+		mapOut := map[string]any{"address": map[string]any{"city": "Berlin", "zip": "10115"}}
+		err := r.ValidateAndUpdateWithValidation(map[string]any{"address": map[string]any{"city": "Hamburg"}}, &mapOut, validations)
+		assert.NoError(t, err, "Expected no error but got one")
+		assert.Equal(t, map[string]any{"city": "Hamburg"}, mapOut["address"], "Expected address to be replaced")
+	})
+
+	t.Run("Deep merge keeps siblings", func(t *testing.T) {
+		r := NewValidator()
+		err := r.SetMergeStrategy(model.MergeDeep)
+		require.NoError(t, err, "Expected no error setting merge strategy")
+
+		mapOut := map[string]any{"address": map[string]any{"city": "Berlin", "zip": "10115"}}
+		err = r.ValidateAndUpdateWithValidation(map[string]any{"address": map[string]any{"city": "Hamburg"}}, &mapOut, validations)
+		assert.NoError(t, err, "Expected no error but got one")
+		assert.Equal(t, map[string]any{"city": "Hamburg", "zip": "10115"}, mapOut["address"], "Expected address to be merged")
+	})
+
+	t.Run("Invalid merge strategy", func(t *testing.T) {
+		r := NewValidator()
+		assert.Error(t, r.SetMergeStrategy(model.MergeStrategy("invalid")), "Expected error for invalid merge strategy")
+		assert.Error(t, r.SetMergeStrategy(model.MergeArraysByKey), "Expected error for missing merge key")
+		assert.NoError(t, r.SetMergeStrategy(model.MergeArraysByKey, "id"), "Expected no error with merge key")
+		assert.Equal(t, "id", r.MergeKey, "Expected merge key to be set")
+	})
+}
+
 func TestValidateWithValidation(t *testing.T) {
 	type args struct {
 		jsonMap     map[string]any
@@ -723,6 +811,42 @@ func TestValidateValueWithParser(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Valid value FROM list literal",
+			args: args{
+				input: "green apple",
+				validation: &model.Validation{
+					Key:         "fruit",
+					Type:        model.String,
+					Requirement: `frm['banana', "green apple"]`,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid value NOT_FROM list literal",
+			args: args{
+				input: 2,
+				validation: &model.Validation{
+					Key:         "count",
+					Type:        model.Int,
+					Requirement: "nfr[1, 2, 3]",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid validation MIN with non numeric value",
+			args: args{
+				input: 2,
+				validation: &model.Validation{
+					Key:         "count",
+					Type:        model.Int,
+					Requirement: "minone",
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -1198,3 +1322,395 @@ func TestRunValidatorsOnConditionGroup(t *testing.T) {
 		})
 	}
 }
+
+type testTenantKey struct{}
+
+func TestValidationFuncCtx(t *testing.T) {
+	v := NewValidator()
+	v.AddValidationFuncCtx(func(ctx context.Context, input any, astValue *model.AstValue) error {
+		if ctx.Value(testTenantKey{}) != "tenant1" {
+			return fmt.Errorf("unknown tenant")
+		}
+		if input == "taken" {
+			return fmt.Errorf("name already taken")
+		}
+		return nil
+	}, "Unique")
+	v.AddValidationFuncCtx(func(ctx context.Context, input any, astValue *model.AstValue) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, "Slow", 10*time.Millisecond)
+
+	type user struct {
+		Name string `json:"name" vld:"min1 funUnique"`
+	}
+	type slowUser struct {
+		Name string `json:"name" vld:"funSlow || min1"`
+	}
+	tenantCtx := context.WithValue(context.Background(), testTenantKey{}, "tenant1")
+
+	t.Run("Valid with context value", func(t *testing.T) {
+		err := v.ValidateContext(tenantCtx, &user{Name: "apple"})
+		assert.NoError(t, err, "Expected no error with tenant in context")
+	})
+
+	t.Run("Invalid with context value", func(t *testing.T) {
+		err := v.ValidateContext(tenantCtx, &user{Name: "taken"})
+		assert.Error(t, err, "Expected error for taken name")
+		assert.True(t, model.IsValidationError(err), "Expected validation error")
+	})
+
+	t.Run("Invalid without context value", func(t *testing.T) {
+		err := v.Validate(&user{Name: "apple"})
+		assert.Error(t, err, "Expected error without tenant in context")
+	})
+
+	t.Run("Timeout is not collected by or group", func(t *testing.T) {
+		err := v.ValidateContext(tenantCtx, &slowUser{Name: "apple"})
+		assert.ErrorIs(t, err, context.DeadlineExceeded, "Expected deadline exceeded error")
+		assert.False(t, model.IsValidationError(err), "Expected timeout not to be a validation error")
+	})
+
+	t.Run("Canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(tenantCtx)
+		cancel()
+		_, err := v.ValidateWithValidationContext(ctx, map[string]any{"name": "apple"}, []model.Validation{{Key: "name", Type: model.String, Requirement: "funUnique"}})
+		assert.ErrorIs(t, err, context.Canceled, "Expected canceled error")
+	})
+
+	t.Run("Remove timeout", func(t *testing.T) {
+		v.SetValidationFuncTimeout("Slow", 0)
+		assert.NotContains(t, v.ValidationFuncTimeouts, "Slow", "Expected timeout to be removed")
+	})
+}
+
+func TestValidationFuncArgs(t *testing.T) {
+	v := NewValidator()
+	v.AddValidationFunc(func(input any, astValue *model.AstValue) error {
+		divisor, err := helper.AnyToFloat(astValue.ConditionArgs[0])
+		if err != nil {
+			return err
+		}
+		value, err := helper.AnyToFloat(input)
+		if err != nil {
+			return err
+		}
+		if math.Mod(value, divisor) != 0 {
+			return fmt.Errorf("value %v is not divisible by %v", value, divisor)
+		}
+		return nil
+	}, "divisible")
+	v.SetValidationFuncArity("divisible", 1)
+
+	type order struct {
+		Amount int `json:"amount" vld:"fun:divisible(5), gr1min1"`
+	}
+	type invalidOrder struct {
+		Amount int `json:"amount" vld:"fun:divisible(5, 6)"`
+	}
+
+	t.Run("Valid with argument", func(t *testing.T) {
+		err := v.Validate(&order{Amount: 15})
+		assert.NoError(t, err, "Expected no error for divisible amount")
+	})
+
+	t.Run("Invalid with argument", func(t *testing.T) {
+		err := v.Validate(&order{Amount: 12})
+		assert.Error(t, err, "Expected error for not divisible amount")
+		assert.True(t, model.IsValidationError(err), "Expected validation error")
+	})
+
+	t.Run("Invalid arity", func(t *testing.T) {
+		err := v.Validate(&invalidOrder{Amount: 15})
+		assert.ErrorContains(t, err, "expected 1 arguments, got: 2", "Expected arity error")
+	})
+
+	t.Run("Invalid arguments for function without arity", func(t *testing.T) {
+		v.AddValidationFunc(func(input any, astValue *model.AstValue) error { return nil }, "check")
+		_, err := v.ValidateWithValidation(map[string]any{"amount": 15}, []model.Validation{{Key: "amount", Type: model.Int, Requirement: "fun:check(1)"}})
+		assert.ErrorContains(t, err, "expected 0 arguments, got: 1", "Expected arity error")
+	})
+}
+
+func TestAddConditionType(t *testing.T) {
+	v := NewValidator()
+	err := v.AddConditionType("currency", CustomConditionType{
+		Validate: func(input any, astValue *model.AstValue) error {
+			if !slices.Contains(astValue.ParsedValue.([]string), input.(string)) {
+				return fmt.Errorf("currency %v not allowed", input)
+			}
+			return nil
+		},
+		ParseValue: func(value string) (any, error) {
+			currencies := strings.Split(value, ",")
+			for _, currency := range currencies {
+				if len(currency) != 3 {
+					return nil, fmt.Errorf("invalid currency code %s", currency)
+				}
+			}
+			return currencies, nil
+		},
+		Description: "currency code from the list",
+	})
+	require.NoError(t, err, "Expected no error adding condition type")
+
+	type payment struct {
+		Currency string `json:"currency" vld:"currencyEUR,USD"`
+	}
+	type invalidPayment struct {
+		Currency string `json:"currency" vld:"currencyEURO"`
+	}
+
+	t.Run("Valid custom condition", func(t *testing.T) {
+		err := v.Validate(&payment{Currency: "EUR"})
+		assert.NoError(t, err, "Expected no error for allowed currency")
+	})
+
+	t.Run("Invalid custom condition", func(t *testing.T) {
+		err := v.Validate(&payment{Currency: "GBP"})
+		assert.Error(t, err, "Expected error for not allowed currency")
+		assert.True(t, model.IsValidationError(err), "Expected validation error")
+	})
+
+	t.Run("Invalid custom condition value", func(t *testing.T) {
+		err := v.Validate(&invalidPayment{Currency: "EUR"})
+		assert.ErrorContains(t, err, "invalid currency code EURO", "Expected value parser error")
+	})
+
+	t.Run("Unknown custom condition type on other validator", func(t *testing.T) {
+		err := NewValidator().Validate(&payment{Currency: "EUR"})
+		assert.Error(t, err, "Expected error for unknown condition type")
+	})
+
+	t.Run("Invalid condition types", func(t *testing.T) {
+		validate := func(input any, astValue *model.AstValue) error { return nil }
+		assert.Error(t, v.AddConditionType("cu", CustomConditionType{Validate: validate}), "Expected error for short name")
+		assert.Error(t, v.AddConditionType("Currency", CustomConditionType{Validate: validate}), "Expected error for upper case name")
+		assert.Error(t, v.AddConditionType("min", CustomConditionType{Validate: validate}), "Expected error for built-in name")
+		assert.Error(t, v.AddConditionType("sku", CustomConditionType{}), "Expected error without validate function")
+		assert.Error(t, v.AddConditionType("iso_code", CustomConditionType{Validate: validate}), "Expected error for name with underscore")
+	})
+
+	t.Run("Valid camel case condition type", func(t *testing.T) {
+		err := v.AddConditionType("isoCode", CustomConditionType{
+			Validate: func(input any, astValue *model.AstValue) error {
+				if strconv.Itoa(len(input.(string))) != astValue.ConditionValue {
+					return fmt.Errorf("invalid iso code %v", input)
+				}
+				return nil
+			},
+		})
+		require.NoError(t, err, "Expected no error adding camel case condition type")
+
+		type country struct {
+			Code string `json:"code" vld:"isoCode2"`
+		}
+		assert.NoError(t, v.Validate(&country{Code: "DE"}), "Expected no error for valid iso code")
+		assert.Error(t, v.Validate(&country{Code: "DEU"}), "Expected error for invalid iso code")
+	})
+}
+
+func TestDefineAlias(t *testing.T) {
+	v := NewValidator()
+	require.NoError(t, v.DefineAlias("password", "min8 max30 @upper"), "Expected no error defining alias")
+	require.NoError(t, v.DefineAlias("upper", "rex'[A-Z]'"), "Expected no error defining alias")
+	require.NoError(t, v.DefineAlias("pw", "@pw"), "Expected no error defining cyclic alias")
+
+	type user struct {
+		Password string `json:"password" vld:"@password && neq'Password1'"`
+	}
+	type cyclicUser struct {
+		Password string `json:"password" vld:"@pw"`
+	}
+
+	t.Run("Valid alias", func(t *testing.T) {
+		err := v.Validate(&user{Password: "Secret123"})
+		assert.NoError(t, err, "Expected no error for valid password")
+	})
+
+	t.Run("Invalid alias", func(t *testing.T) {
+		err := v.Validate(&user{Password: "secret123"})
+		assert.Error(t, err, "Expected error for password without upper case letter")
+		assert.True(t, model.IsValidationError(err), "Expected validation error")
+	})
+
+	t.Run("Invalid condition beside alias", func(t *testing.T) {
+		err := v.Validate(&user{Password: "Password1"})
+		assert.Error(t, err, "Expected error for forbidden password")
+	})
+
+	t.Run("Invalid alias cycle", func(t *testing.T) {
+		err := v.Validate(&cyclicUser{Password: "Secret123"})
+		assert.ErrorContains(t, err, "cycle in aliases", "Expected cycle error")
+	})
+
+	t.Run("Invalid alias definitions", func(t *testing.T) {
+		assert.Error(t, v.DefineAlias("pass word", "min8"), "Expected error for invalid name")
+		assert.Error(t, v.DefineAlias("empty", " "), "Expected error for empty requirement")
+		assert.Error(t, v.DefineAlias("none", "-"), "Expected error for requirement without condition")
+	})
+}
+
+func TestAddValueList(t *testing.T) {
+	v := NewValidator()
+	require.NoError(t, v.AddValueList("plans", "free", "pro"), "Expected no error adding value list")
+
+	type account struct {
+		Country  string   `json:"country" vld:"frm@countries"`
+		Currency string   `json:"currency" vld:"frm@currencies"`
+		Language []string `json:"language" vld:"frm@languages"`
+		TimeZone string   `json:"time_zone" vld:"frm@timezones"`
+		Plan     string   `json:"plan" vld:"frm@plans && nfr'pro'"`
+	}
+	validAccount := account{Country: "DE", Currency: "EUR", Language: []string{"de", "en"}, TimeZone: "UTC", Plan: "free"}
+
+	t.Run("Valid value lists", func(t *testing.T) {
+		err := v.Validate(&validAccount)
+		assert.NoError(t, err, "Expected no error for values from lists")
+	})
+
+	t.Run("Invalid built-in value list", func(t *testing.T) {
+		invalidAccount := validAccount
+		invalidAccount.Language = []string{"de", "xx"}
+		err := v.Validate(&invalidAccount)
+		assert.Error(t, err, "Expected error for unknown language")
+		assert.True(t, model.IsValidationError(err), "Expected validation error")
+	})
+
+	t.Run("Invalid custom value list", func(t *testing.T) {
+		invalidAccount := validAccount
+		invalidAccount.Plan = "enterprise"
+		err := v.Validate(&invalidAccount)
+		assert.Error(t, err, "Expected error for unknown plan")
+	})
+
+	t.Run("Unknown value list", func(t *testing.T) {
+		err := NewValidator().Validate(&validAccount)
+		assert.ErrorContains(t, err, "unknown value list: @plans", "Expected unknown value list error")
+	})
+
+	t.Run("Invalid value lists", func(t *testing.T) {
+		assert.Error(t, v.AddValueList("my plans", "free"), "Expected error for invalid name")
+		assert.Error(t, v.AddValueList("empty"), "Expected error for empty value list")
+	})
+}
+
+type testStatus string
+
+const (
+	testStatusActive   testStatus = "active"
+	testStatusInactive testStatus = "inactive"
+)
+
+func (testStatus) Values() []any {
+	return []any{testStatusActive, testStatusInactive}
+}
+
+type testPriority int
+
+func (testPriority) Values() []any {
+	return []any{testPriority(1), testPriority(2), testPriority(3)}
+}
+
+func TestValidateEnum(t *testing.T) {
+	type task struct {
+		Status   testStatus         `json:"status" vld:"enum"`
+		Priority testPriority       `json:"priority" vld:"enum"`
+		Labels   []testStatus       `json:"labels" vld:"enum || max0"`
+		Counts   map[testStatus]int `json:"counts" vld:"enum"`
+	}
+	validTask := task{Status: testStatusActive, Priority: 2, Labels: []testStatus{testStatusInactive}, Counts: map[testStatus]int{testStatusActive: 1}}
+
+	t.Run("Valid enums", func(t *testing.T) {
+		err := Validate(&validTask)
+		assert.NoError(t, err, "Expected no error for enum values")
+	})
+
+	t.Run("Invalid enum", func(t *testing.T) {
+		invalidTask := validTask
+		invalidTask.Status = "deleted"
+		err := Validate(&invalidTask)
+		assert.Error(t, err, "Expected error for unknown status")
+		assert.True(t, model.IsValidationError(err), "Expected validation error")
+	})
+
+	t.Run("Invalid enum slice", func(t *testing.T) {
+		invalidTask := validTask
+		invalidTask.Labels = []testStatus{testStatusActive, "deleted"}
+		err := Validate(&invalidTask)
+		assert.Error(t, err, "Expected error for unknown label")
+	})
+
+	t.Run("Invalid enum map key", func(t *testing.T) {
+		invalidTask := validTask
+		invalidTask.Counts = map[testStatus]int{"deleted": 1}
+		err := Validate(&invalidTask)
+		assert.Error(t, err, "Expected error for unknown map key")
+	})
+
+	t.Run("Valid enum from json", func(t *testing.T) {
+		updated := &task{}
+		err := ValidateAndUpdate(map[string]any{"status": "inactive", "priority": 3.0, "labels": []any{}, "counts": map[string]any{"active": 2.0}}, updated)
+		assert.NoError(t, err, "Expected no error for enum values from json")
+		assert.Equal(t, testStatusInactive, updated.Status, "Expected status to be updated")
+	})
+
+	t.Run("Invalid enum from json", func(t *testing.T) {
+		err := ValidateAndUpdate(map[string]any{"status": "active", "priority": 4.0, "labels": []any{}, "counts": map[string]any{}}, &task{})
+		assert.Error(t, err, "Expected error for unknown priority from json")
+	})
+
+	t.Run("Enum values with validation", func(t *testing.T) {
+		_, err := NewValidator().ValidateWithValidation(map[string]any{"plan": "pro"}, []model.Validation{{Key: "plan", Type: model.String, Requirement: "enum", Enum: []any{"free", "pro"}}})
+		assert.NoError(t, err, "Expected no error for value of validation enum")
+	})
+
+	t.Run("Enum without enum type", func(t *testing.T) {
+		_, err := NewValidator().ValidateWithValidation(map[string]any{"plan": "pro"}, []model.Validation{{Key: "plan", Type: model.String, Requirement: "enum"}})
+		assert.ErrorContains(t, err, "has no enum values", "Expected error without enum values")
+	})
+}
+
+func TestValidateWithNamedTagSections(t *testing.T) {
+	type user struct {
+		Name  string `json:"name" vld:"req=min3; msg=name needs at least 3 characters"`
+		Role  string `json:"role" vld:"req=frmguest,admin; default=guest"`
+		Level int    `json:"level" vld:"req=min1 max3; default=1"`
+		Code  string `json:"code" vld:"rex'^[a-z]+, [a-z]+$', gr1min1"`
+		Short string `json:"short" vld:"min"`
+	}
+
+	t.Run("Invalid short requirement is reported", func(t *testing.T) {
+		err := ValidateAndUpdate(map[string]any{"name": "apple", "code": "a, b", "short": "x"}, &user{})
+		require.Error(t, err, "Expected error for invalid short requirement")
+		assert.Contains(t, err.Error(), "requirement of field Short", "Expected error for field with short requirement")
+	})
+
+	type validUser struct {
+		Name  string `json:"name" vld:"req=min3; msg=name needs at least 3 characters"`
+		Role  string `json:"role" vld:"req=frmguest,admin; default=guest"`
+		Level int    `json:"level" vld:"req=min1 max3; default=1"`
+		Code  string `json:"code" vld:"rex'^[a-z]+, [a-z]+$', gr1min1"`
+	}
+
+	t.Run("Valid defaults", func(t *testing.T) {
+		updated := &validUser{}
+		err := ValidateAndUpdate(map[string]any{"name": "apple", "code": "a, b"}, updated)
+		require.NoError(t, err, "Expected no error with defaults")
+		assert.Equal(t, validUser{Name: "apple", Role: "guest", Level: 1, Code: "a, b"}, *updated, "Expected defaults to be set")
+	})
+
+	t.Run("Invalid with custom message", func(t *testing.T) {
+		err := ValidateAndUpdate(map[string]any{"name": "ap", "code": "a, b"}, &validUser{})
+		require.Error(t, err, "Expected error for short name")
+		assert.Contains(t, err.Error(), "field name invalid: name needs at least 3 characters", "Expected custom message")
+		problemErrors := model.ProblemErrorsFromError(err)
+		require.Len(t, problemErrors, 1, "Expected one problem error")
+		assert.Equal(t, "name needs at least 3 characters", problemErrors[0].Message, "Expected custom message in problem error")
+	})
+
+	t.Run("Invalid regex with comma", func(t *testing.T) {
+		err := ValidateAndUpdate(map[string]any{"name": "apple", "code": "a,b"}, &validUser{})
+		assert.Error(t, err, "Expected error for code without space")
+	})
+}
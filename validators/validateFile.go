@@ -0,0 +1,100 @@
+package validators
+
+import (
+	"fmt"
+	"mime/multipart"
+
+	"github.com/siherrmann/validator/helper"
+	"github.com/siherrmann/validator/model"
+)
+
+// ValidateFileMinSize checks if every file is at least the size of the condition (eg. `fmn1KB`).
+func ValidateFileMinSize(v any, ast *model.AstValue) error {
+	minSize, err := helper.ByteSizeToInt(ast.ConditionValue)
+	if err != nil {
+		return err
+	}
+
+	return validateFiles(v, func(file *multipart.FileHeader) error {
+		if file.Size < minSize {
+			return fmt.Errorf("file %v smaller than minimum size %v", file.Filename, ast.ConditionValue)
+		}
+		return nil
+	})
+}
+
+// ValidateFileMaxSize checks if every file is at most the size of the condition (eg. `fmx5MB`).
+func ValidateFileMaxSize(v any, ast *model.AstValue) error {
+	maxSize, err := helper.ByteSizeToInt(ast.ConditionValue)
+	if err != nil {
+		return err
+	}
+
+	return validateFiles(v, func(file *multipart.FileHeader) error {
+		if file.Size > maxSize {
+			return fmt.Errorf("file %v greater than maximum size %v", file.Filename, ast.ConditionValue)
+		}
+		return nil
+	})
+}
+
+// ValidateFileMime checks if the declared Content-Type of every file
+// is in the comma separated list of the condition (eg. `mimimage/png,image/jpeg` or `mimimage/*`).
+func ValidateFileMime(v any, ast *model.AstValue) error {
+	return validateFiles(v, func(file *multipart.FileHeader) error {
+		mediaType := file.Header.Get("Content-Type")
+		if !MimeTypeFrom(mediaType, ast.ConditionValue) {
+			return fmt.Errorf("file %v with media type %v not in %v", file.Filename, mediaType, ast.ConditionValue)
+		}
+		return nil
+	})
+}
+
+// ValidateFileSniff checks if the media type detected from the content of every file
+// is in the comma separated list of the condition (eg. `snfimage/png,image/jpeg` or `snfimage/*`).
+func ValidateFileSniff(v any, ast *model.AstValue) error {
+	return validateFiles(v, func(file *multipart.FileHeader) error {
+		mediaType, err := SniffMimeType(file)
+		if err != nil {
+			return err
+		}
+		if !MimeTypeFrom(mediaType, ast.ConditionValue) {
+			return fmt.Errorf("file %v with detected media type %v not in %v", file.Filename, mediaType, ast.ConditionValue)
+		}
+		return nil
+	})
+}
+
+// ValidateFileName checks if the name of every file matches the regex of the condition (eg. `fnm\.pdf$`).
+func ValidateFileName(v any, ast *model.AstValue) error {
+	return validateFiles(v, func(file *multipart.FileHeader) error {
+		if !Regex(file.Filename, ast.ConditionValue) {
+			return fmt.Errorf("file name %v does not match regex %v", file.Filename, ast.ConditionValue)
+		}
+		return nil
+	})
+}
+
+// validateFiles runs the check on the file or on every file of a slice of files.
+func validateFiles(v any, check func(file *multipart.FileHeader) error) error {
+	switch files := v.(type) {
+	case *multipart.FileHeader:
+		if files == nil {
+			return fmt.Errorf("file is missing")
+		}
+		return check(files)
+	case []*multipart.FileHeader:
+		for _, file := range files {
+			if file == nil {
+				return fmt.Errorf("file is missing")
+			}
+			err := check(file)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("value to validate has to be a file or a slice of files, was %T", v)
+	}
+}
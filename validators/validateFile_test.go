@@ -0,0 +1,117 @@
+package validators
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/textproto"
+	"testing"
+
+	"github.com/siherrmann/validator/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestFile creates a parsed multipart file header with the given name, content type and content.
+func newTestFile(t *testing.T, filename string, contentType string, content []byte) *multipart.FileHeader {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="file"; filename="`+filename+`"`)
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	require.NoError(t, err, "Expected no error creating part")
+	_, err = part.Write(content)
+	require.NoError(t, err, "Expected no error writing part")
+	require.NoError(t, writer.Close(), "Expected no error closing writer")
+
+	form, err := multipart.NewReader(body, writer.Boundary()).ReadForm(1 << 20)
+	require.NoError(t, err, "Expected no error reading form")
+	return form.File["file"][0]
+}
+
+func TestValidateFile(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n0000")
+	text := []byte("hello world")
+
+	type args struct {
+		v        any
+		ast      *model.AstValue
+		validate func(v any, ast *model.AstValue) error
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "Valid min size",
+			args:    args{v: newTestFile(t, "a.txt", "text/plain", text), ast: &model.AstValue{ConditionValue: "10B"}, validate: ValidateFileMinSize},
+			wantErr: false,
+		},
+		{
+			name:    "Invalid min size",
+			args:    args{v: newTestFile(t, "a.txt", "text/plain", text), ast: &model.AstValue{ConditionValue: "1KB"}, validate: ValidateFileMinSize},
+			wantErr: true,
+		},
+		{
+			name:    "Valid max size",
+			args:    args{v: newTestFile(t, "a.txt", "text/plain", text), ast: &model.AstValue{ConditionValue: "1KB"}, validate: ValidateFileMaxSize},
+			wantErr: false,
+		},
+		{
+			name:    "Invalid max size in slice",
+			args:    args{v: []*multipart.FileHeader{newTestFile(t, "a.txt", "text/plain", text), newTestFile(t, "b.png", "image/png", png)}, ast: &model.AstValue{ConditionValue: "11"}, validate: ValidateFileMaxSize},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid size condition",
+			args:    args{v: newTestFile(t, "a.txt", "text/plain", text), ast: &model.AstValue{ConditionValue: "1XB"}, validate: ValidateFileMaxSize},
+			wantErr: true,
+		},
+		{
+			name:    "Valid declared mime type with wildcard",
+			args:    args{v: newTestFile(t, "b.png", "image/png", png), ast: &model.AstValue{ConditionValue: "image/*"}, validate: ValidateFileMime},
+			wantErr: false,
+		},
+		{
+			name:    "Invalid declared mime type",
+			args:    args{v: newTestFile(t, "a.txt", "text/plain", text), ast: &model.AstValue{ConditionValue: "image/png,image/jpeg"}, validate: ValidateFileMime},
+			wantErr: true,
+		},
+		{
+			name:    "Valid sniffed mime type",
+			args:    args{v: newTestFile(t, "b.png", "text/plain", png), ast: &model.AstValue{ConditionValue: "image/png"}, validate: ValidateFileSniff},
+			wantErr: false,
+		},
+		{
+			name:    "Invalid sniffed mime type with declared image",
+			args:    args{v: newTestFile(t, "a.png", "image/png", text), ast: &model.AstValue{ConditionValue: "image/*"}, validate: ValidateFileSniff},
+			wantErr: true,
+		},
+		{
+			name:    "Valid file name",
+			args:    args{v: newTestFile(t, "report.pdf", "application/pdf", text), ast: &model.AstValue{ConditionValue: `\.pdf$`}, validate: ValidateFileName},
+			wantErr: false,
+		},
+		{
+			name:    "Invalid file name",
+			args:    args{v: newTestFile(t, "report.exe", "application/pdf", text), ast: &model.AstValue{ConditionValue: `\.pdf$`}, validate: ValidateFileName},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid value type",
+			args:    args{v: "report.pdf", ast: &model.AstValue{ConditionValue: `\.pdf$`}, validate: ValidateFileName},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.args.validate(test.args.v, test.args.ast)
+			if test.wantErr {
+				assert.Error(t, err, "Expected error but got none")
+			} else {
+				assert.NoError(t, err, "Expected no error but got one")
+			}
+		})
+	}
+}
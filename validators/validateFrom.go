@@ -2,16 +2,20 @@ package validators
 
 import (
 	"fmt"
+	"reflect"
 
+	"github.com/siherrmann/validator/helper"
 	"github.com/siherrmann/validator/model"
 )
 
 func ValidateFrom[T any](v T, ast *model.AstValue) error {
-	from, err := From(v, ast.ConditionValue, false)
+	from, err := fromCondition(v, ast, false)
 	if err != nil {
 		return fmt.Errorf("error checking from: %v", err)
 	}
-	if !from {
+	if !from && ast.ConditionType == model.ENUM {
+		return fmt.Errorf("value is not a value of the enum")
+	} else if !from {
 		return fmt.Errorf("from %v does not contain value", ast.ConditionValue)
 	}
 
@@ -19,7 +23,7 @@ func ValidateFrom[T any](v T, ast *model.AstValue) error {
 }
 
 func ValidateNotFrom[T any](v T, ast *model.AstValue) error {
-	notFrom, err := From(v, ast.ConditionValue, true)
+	notFrom, err := fromCondition(v, ast, true)
 	if err != nil {
 		return fmt.Errorf("error checking not from: %v", err)
 	}
@@ -29,3 +33,23 @@ func ValidateNotFrom[T any](v T, ast *model.AstValue) error {
 
 	return nil
 }
+
+// fromCondition checks the value against the named value list of the condition (eg. `frm@countries`),
+// the values of an `enum` condition or against the comma separated condition value.
+// If an `enum` condition has no values they are taken from the type of the value (see model.Enum).
+func fromCondition(v any, ast *model.AstValue, not bool) (bool, error) {
+	if valueSet, ok := ast.ParsedValue.(model.ValueSet); ok {
+		return FromSet(v, valueSet, not)
+	} else if ast.ConditionType == model.ENUM {
+		values, ok := helper.EnumValues(reflect.TypeOf(v))
+		if !ok {
+			return false, fmt.Errorf("type %T has no enum values", v)
+		}
+		valueSet, err := helper.EnumValueSet(values)
+		if err != nil {
+			return false, err
+		}
+		return FromSet(v, valueSet, not)
+	}
+	return From(v, ast.ConditionValue, not)
+}
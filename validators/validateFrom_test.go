@@ -7,6 +7,12 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+type testStatus string
+
+func (testStatus) Values() []any {
+	return []any{testStatus("active"), testStatus("inactive")}
+}
+
 func TestValidateFrom(t *testing.T) {
 	type args struct {
 		v   any
@@ -41,6 +47,70 @@ func TestValidateFrom(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Valid enum",
+			args: args{
+				v:   testStatus("active"),
+				ast: &model.AstValue{ConditionType: model.ENUM},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Valid enum slice",
+			args: args{
+				v:   []testStatus{"active", "inactive"},
+				ast: &model.AstValue{ConditionType: model.ENUM},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Valid enum map keys",
+			args: args{
+				v:   map[testStatus]int{"active": 1},
+				ast: &model.AstValue{ConditionType: model.ENUM},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid enum",
+			args: args{
+				v:   testStatus("deleted"),
+				ast: &model.AstValue{ConditionType: model.ENUM},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Valid enum with parsed values",
+			args: args{
+				v:   "active",
+				ast: &model.AstValue{ConditionType: model.ENUM, ParsedValue: model.NewValueSet("active")},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid enum without enum type",
+			args: args{
+				v:   "active",
+				ast: &model.AstValue{ConditionType: model.ENUM},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Valid from value list",
+			args: args{
+				v:   "DE",
+				ast: &model.AstValue{ConditionValue: "@countries", ParsedValue: model.NewValueSet("DE", "FR")},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid from value list",
+			args: args{
+				v:   "XX",
+				ast: &model.AstValue{ConditionValue: "@countries", ParsedValue: model.NewValueSet("DE", "FR")},
+			},
+			wantErr: true,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -88,6 +158,22 @@ func TestValidateNotFrom(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Valid not from value list",
+			args: args{
+				v:   "XX",
+				ast: &model.AstValue{ConditionValue: "@countries", ParsedValue: model.NewValueSet("DE", "FR")},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid not from value list",
+			args: args{
+				v:   "DE",
+				ast: &model.AstValue{ConditionValue: "@countries", ParsedValue: model.NewValueSet("DE", "FR")},
+			},
+			wantErr: true,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
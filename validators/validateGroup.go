@@ -17,7 +17,7 @@ func ValidateGroups(groups map[string]*model.Group, groupSize map[string]int, gr
 					if err != nil {
 						return err
 					} else if (groupSize[groupName] - len(groupErrors[groupName])) < minValue {
-						return fmt.Errorf("less then %v in group %s without error, all errors: %v", minValue, groupName, groupErrors[groupName])
+						return &model.GroupError{Group: group, Errors: groupErrors[groupName]}
 					}
 				}
 			case model.MAX_VALUE:
@@ -26,7 +26,7 @@ func ValidateGroups(groups map[string]*model.Group, groupSize map[string]int, gr
 					if err != nil {
 						return err
 					} else if (groupSize[groupName] - len(groupErrors[groupName])) > maxValue {
-						return fmt.Errorf("more then %v in group %s without error, all errors: %v", maxValue, groupName, groupErrors[groupName])
+						return &model.GroupError{Group: group, Errors: groupErrors[groupName]}
 					}
 				}
 			default:
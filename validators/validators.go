@@ -2,12 +2,17 @@ package validators
 
 import (
 	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
 	"reflect"
 	"regexp"
 	"slices"
 	"strings"
 
 	"github.com/siherrmann/validator/helper"
+	"github.com/siherrmann/validator/model"
 )
 
 func Equal[T comparable](a, equal T) bool {
@@ -127,7 +132,76 @@ func FromArray[T comparable](v []T, from []T, not bool) (bool, error) {
 	return true, nil
 }
 
+// FromSet checks if the value (or every element of an array or every key of a map)
+// is in the value set, or is not in the value set if not is true.
+// Values are compared by their key in the value set (see helper.AnyToValueSetKey).
+func FromSet(v any, valueSet model.ValueSet, not bool) (bool, error) {
+	if v == nil {
+		return false, fmt.Errorf("nil not supported for From validation")
+	}
+
+	values := []any{v}
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Array, reflect.Slice:
+		values = []any{}
+		rv := reflect.ValueOf(v)
+		for i := 0; i < rv.Len(); i++ {
+			values = append(values, rv.Index(i).Interface())
+		}
+	case reflect.Map:
+		values = []any{}
+		for _, mk := range reflect.ValueOf(v).MapKeys() {
+			values = append(values, mk.Interface())
+		}
+	}
+
+	for _, value := range values {
+		s, err := helper.AnyToValueSetKey(value)
+		if err != nil {
+			return false, fmt.Errorf("type %v not supported for From validation", reflect.TypeOf(value))
+		}
+		if not == valueSet.Contains(s) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 func Regex(s, regex string) bool {
 	matched, _ := regexp.MatchString(regex, s)
 	return matched
 }
+
+// MimeTypeFrom checks if the media type matches one of the comma separated media types.
+// Media types can use a wildcard subtype (eg. `image/*`), parameters are ignored.
+func MimeTypeFrom(mediaType string, from string) bool {
+	if parsed, _, err := mime.ParseMediaType(mediaType); err == nil {
+		mediaType = parsed
+	}
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+
+	for _, f := range strings.Split(from, ",") {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if f == mediaType || (strings.HasSuffix(f, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(f, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// SniffMimeType reads the first 512 bytes of the file and returns the media type
+// detected by http.DetectContentType.
+func SniffMimeType(file *multipart.FileHeader) (string, error) {
+	f, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("error opening file %v: %v", file.Filename, err)
+	}
+	defer f.Close()
+
+	buffer := make([]byte, 512)
+	n, err := io.ReadFull(f, buffer)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", fmt.Errorf("error reading file %v: %v", file.Filename, err)
+	}
+	return http.DetectContentType(buffer[:n]), nil
+}
@@ -3,6 +3,7 @@ package validators
 import (
 	"testing"
 
+	"github.com/siherrmann/validator/model"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -2187,3 +2188,45 @@ func TestFrom(t *testing.T) {
 		})
 	}
 }
+
+func TestMimeTypeFrom(t *testing.T) {
+	assert.True(t, MimeTypeFrom("image/png", "image/png,image/jpeg"), "Expected exact media type to match")
+	assert.True(t, MimeTypeFrom("Image/PNG; charset=binary", "image/*"), "Expected wildcard media type to match")
+	assert.False(t, MimeTypeFrom("text/plain", "image/*"), "Expected other media type not to match")
+	assert.False(t, MimeTypeFrom("", "image/png"), "Expected empty media type not to match")
+}
+
+func TestFromSet(t *testing.T) {
+	valueSet := model.NewValueSet("de", "en", "1")
+
+	tests := []struct {
+		name     string
+		v        any
+		not      bool
+		expected bool
+		wantErr  bool
+	}{
+		{name: "Valid string", v: "de", expected: true},
+		{name: "Invalid string", v: "es", expected: false},
+		{name: "Valid int", v: 1, expected: true},
+		{name: "Valid array", v: []string{"de", "en"}, expected: true},
+		{name: "Invalid array", v: []string{"de", "es"}, expected: false},
+		{name: "Valid map keys", v: map[string]int{"de": 1}, expected: true},
+		{name: "Valid not", v: "es", not: true, expected: true},
+		{name: "Invalid not array", v: []string{"es", "en"}, not: true, expected: false},
+		{name: "Invalid type", v: []any{map[string]any{}}, wantErr: true},
+		{name: "Invalid nil", v: nil, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			from, err := FromSet(test.v, valueSet, test.not)
+			if test.wantErr {
+				assert.Error(t, err, "Expected error but got none")
+			} else {
+				assert.NoError(t, err, "Expected no error but got one")
+				assert.Equal(t, test.expected, from, "Expected from result to match")
+			}
+		})
+	}
+}